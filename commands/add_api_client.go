@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"infocusp-projects/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// AddAPIClientCmd defines a Cobra command that generates a typed TypeScript API
+// client and React hooks into a React project, from an OpenAPI document, from
+// resource definitions in the syntax of `add resource`, or from the resources
+// `add resource` recorded in the backend's manifest. Running it again after the
+// API changes regenerates the client in place.
+func AddAPIClientCmd() *cobra.Command {
+	var projectDir, openAPIPath, backendDir string
+	var resourceDefinitions []string
+
+	cmd := &cobra.Command{
+		Use:   "api-client",
+		Short: "Generate a typed TypeScript API client and hooks into a React project",
+		Long: `Generate a typed TypeScript API client and hooks into a React project.
+
+The client is written to src/api/generated/client.ts and the hooks, using the
+project's data-fetching library, to src/api/generated/hooks.ts. Describe the API
+with an OpenAPI document, or with the resources added to a FastAPI backend. Without
+either, the resources recorded by add resource in the backend are used:
+
+  infocusp add api-client --from-openapi ../backend/openapi.yaml
+  infocusp add api-client --resource "Order id:int total:float status:str"
+  infocusp add api-client --backend ../backend`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if openAPIPath != "" && len(resourceDefinitions) > 0 {
+				fmt.Println("Error: pass either --from-openapi or --resource")
+				return
+			}
+
+			var spec *generator.OpenAPISpec
+			var err error
+			switch {
+			case openAPIPath != "":
+				spec, err = generator.ReadOpenAPI(openAPIPath)
+			case len(resourceDefinitions) == 0:
+				// Use the resources recorded in the backend, next to the frontend by default
+				if backendDir == "" {
+					backendDir = filepath.Join(projectDir, "..", "backend")
+				}
+				resources, recordErr := generator.RecordedResources(backendDir)
+				if recordErr != nil {
+					fmt.Println("Error reading recorded resources:", recordErr)
+					return
+				}
+				spec, err = generator.ResourcesOpenAPI(resources)
+			default:
+				var resources []generator.Resource
+				for _, definition := range resourceDefinitions {
+					resource, parseErr := generator.ParseResource(definition)
+					if parseErr != nil {
+						fmt.Println("Error parsing resource:", parseErr)
+						return
+					}
+					resources = append(resources, resource)
+				}
+				spec, err = generator.ResourcesOpenAPI(resources)
+			}
+			if err != nil {
+				fmt.Println("Error reading API description:", err)
+				return
+			}
+
+			err = generator.GenerateAPIClient(cmd.Context(), generator.APIClientOptions{
+				ProjectDir: projectDir,
+				Spec:       spec,
+				Stdout:     os.Stdout,
+			})
+			if err != nil {
+				fmt.Println("Error generating API client:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&projectDir, "dir", ".", "Directory of the React project")
+	cmd.Flags().StringVar(&openAPIPath, "from-openapi", "", "OpenAPI 3 document (YAML or JSON) describing the API")
+	cmd.Flags().StringArrayVar(&resourceDefinitions, "resource", nil, `Resource as "Name field:type ...", repeatable`)
+	cmd.Flags().StringVar(&backendDir, "backend", "", "Backend whose recorded resources describe the API, without --from-openapi or --resource (default ../backend of --dir)")
+	return cmd
+}
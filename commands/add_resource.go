@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"infocusp-projects/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// AddCmd defines the Cobra command grouping generators that add code to an
+// existing project.
+func AddCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add code to a project generated by this CLI",
+	}
+	cmd.AddCommand(AddResourceCmd())
+	cmd.AddCommand(AddAPIClientCmd())
+	return cmd
+}
+
+// AddResourceCmd defines a Cobra command that adds a CRUD resource to a Flask or
+// FastAPI skeleton project. It detects the stack from app/main.py, then generates
+// the model, schema, router or blueprint, its registration in main.py, and tests.
+func AddResourceCmd() *cobra.Command {
+	var projectDir string
+
+	cmd := &cobra.Command{
+		Use:   "resource <Name> <field:type>...",
+		Short: "Add a model, schema, CRUD endpoints and tests to a Flask or FastAPI project",
+		Long: `Add a model, schema, CRUD endpoints and tests to a Flask or FastAPI project.
+
+Fields are written as name:type, where type is one of int, float, str, bool,
+date or datetime. For example:
+
+  infocusp add resource Order id:int total:float status:str`,
+		Args: cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			fields, err := generator.ParseFields(args[1:])
+			if err != nil {
+				fmt.Println("Error parsing fields:", err)
+				return
+			}
+
+			err = generator.AddResource(cmd.Context(), generator.ResourceOptions{
+				ProjectDir: projectDir,
+				Name:       args[0],
+				Fields:     fields,
+				Stdout:     os.Stdout,
+			})
+			if err != nil {
+				fmt.Println("Error adding resource:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&projectDir, "dir", ".", "Directory of the Flask or FastAPI project")
+	return cmd
+}
@@ -0,0 +1,14 @@
+package commands
+
+import (
+	"strings"
+
+	"infocusp-projects/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// addCIFlag adds the flag choosing the CI service a pipeline is generated for.
+func addCIFlag(cmd *cobra.Command, ci *string) {
+	cmd.Flags().StringVar(ci, "ci", "", "Generate a CI pipeline for this service ("+strings.Join(generator.CIProviders, ", ")+")")
+}
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"infocusp-projects/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// DemoCmd groups the commands browsing and creating the demo projects of the
+// catalog. The built-in catalog is extended by a local one, read from the
+// --catalog flag or the default path.
+func DemoCmd() *cobra.Command {
+	var catalog string
+
+	cmd := &cobra.Command{
+		Use:   "demo",
+		Short: "List, describe and create ready-to-use demo projects",
+	}
+
+	defaultCatalog, _ := generator.DefaultDemoCatalogPath()
+	cmd.PersistentFlags().StringVar(&catalog, "catalog", defaultCatalog, "local demo catalog extending the built-in one")
+
+	// Only a catalog passed explicitly has to exist
+	loadCatalog := func(cmd *cobra.Command) ([]generator.Demo, error) {
+		path := catalog
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) && !cmd.Flags().Changed("catalog") {
+			path = ""
+		}
+		return generator.LoadDemoCatalog(path)
+	}
+
+	cmd.AddCommand(DemoListCmd(loadCatalog))
+	cmd.AddCommand(DemoInfoCmd(loadCatalog))
+	cmd.AddCommand(DemoCreateCmd(loadCatalog))
+	return cmd
+}
+
+// demoCatalogLoader loads the demo catalog selected by the flags of cmd.
+type demoCatalogLoader func(cmd *cobra.Command) ([]generator.Demo, error)
+
+// DemoListCmd defines a Cobra command listing the demos of the catalog.
+func DemoListCmd(loadCatalog demoCatalogLoader) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the demo projects of the catalog",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			demos, err := loadCatalog(cmd)
+			if err != nil {
+				fmt.Println("Error loading demo catalog:", err)
+				return
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tSOURCE\tDESCRIPTION")
+			for _, demo := range demos {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", demo.Name, demo.Source(), demo.Description)
+			}
+			w.Flush()
+		},
+	}
+}
+
+// DemoInfoCmd defines a Cobra command describing a demo of the catalog: where it
+// comes from, its setup steps and how to run it.
+func DemoInfoCmd(loadCatalog demoCatalogLoader) *cobra.Command {
+	return &cobra.Command{
+		Use:   "info <name>",
+		Short: "Describe a demo project, its setup steps and how to run it",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			demos, err := loadCatalog(cmd)
+			if err != nil {
+				fmt.Println("Error loading demo catalog:", err)
+				return
+			}
+			demo, err := generator.FindDemo(demos, args[0])
+			if err != nil {
+				fmt.Println("Error finding demo:", err)
+				return
+			}
+
+			fmt.Printf("%s: %s\n\n", demo.Name, demo.Description)
+			if demo.Repo != "" {
+				fmt.Printf("Cloned from %s\n", demo.Repo)
+			} else {
+				fmt.Printf("Generated with `infocusp %s`", demo.Template)
+				if len(demo.Options) > 0 {
+					options := make([]string, 0, len(demo.Options))
+					for key, value := range demo.Options {
+						options = append(options, key+"="+value)
+					}
+					sort.Strings(options)
+					fmt.Printf(" (%s)", strings.Join(options, ", "))
+				}
+				fmt.Println()
+			}
+
+			if len(demo.Setup) > 0 {
+				fmt.Println("\nSetup steps:")
+				for _, step := range demo.Setup {
+					fmt.Printf("  %s\n", step)
+				}
+			}
+			if demo.Instructions != "" {
+				fmt.Println("\nRunning it:")
+				for _, line := range strings.Split(strings.TrimRight(demo.Instructions, "\n"), "\n") {
+					fmt.Println(strings.TrimRight("  "+line, " "))
+				}
+			}
+		},
+	}
+}
+
+// DemoCreateCmd defines a Cobra command creating a demo of the catalog in the
+// current directory, from its template or by cloning its repository with CloneRepo,
+// and running its setup steps.
+func DemoCreateCmd(loadCatalog demoCatalogLoader) *cobra.Command {
+	var projectName string
+	var skipSetup bool
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a demo project and run its setup steps",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			demos, err := loadCatalog(cmd)
+			if err != nil {
+				fmt.Println("Error loading demo catalog:", err)
+				return
+			}
+			demo, err := generator.FindDemo(demos, args[0])
+			if err != nil {
+				fmt.Println("Error finding demo:", err)
+				return
+			}
+
+			// Create the demo in the current directory
+			err = generator.CreateDemo(cmd.Context(), generator.DemoOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdin:  os.Stdin,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+				},
+				Demo:      demo,
+				Clone:     CloneRepo,
+				SkipSetup: skipSetup,
+			})
+			if err != nil {
+				fmt.Println("Error creating demo:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&projectName, "name", "", "project directory to create (default the demo name)")
+	cmd.Flags().BoolVar(&skipSetup, "skip-setup", false, "print the setup steps instead of running them")
+	return cmd
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// CreateDemoAppCmd defines a Cobra command to generate a demo app for rapid prototyping.
+// It prompts the user for a project name and Streamlit or Gradio, and generates a data
+// explorer and a model playground backed by sample data and model hooks.
+func CreateDemoAppCmd() *cobra.Command {
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-demo-app",
+		Short: "Create a Streamlit or Gradio demo with multiple pages, data and model hooks, and Docker",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Prompt the user to input the project name
+			prompt := promptui.Prompt{
+				Label: "Project Name",
+			}
+			projectName, _ := prompt.Run()
+
+			// Prompt for framework
+			frameworkPrompt := promptui.Select{
+				Label: "Choose a demo framework",
+				Items: generator.DemoFrameworks,
+			}
+			_, framework, _ := frameworkPrompt.Run()
+
+			// Generate the project in the current directory
+			err = generator.CreateDemoApp(cmd.Context(), generator.DemoAppOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+					Git:    git,
+					CI:     ci,
+				},
+				Framework: framework,
+			})
+			if err != nil {
+				fmt.Println("Error creating demo app:", err)
+				return
+			}
+		},
+	}
+
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+	return cmd
+}
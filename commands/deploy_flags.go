@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"strings"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// deployFlags holds the flags that add deployment configuration to the
+// Python skeletons.
+type deployFlags struct {
+	target     string
+	project    string
+	region     string
+	repository string
+}
+
+// register adds the deployment flags to cmd.
+func (f *deployFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.target, "deploy", "", "Generate deployment configuration ("+strings.Join(generator.DeployTargets, ", ")+")")
+	cmd.Flags().StringVar(&f.project, "gcp-project", "", "Google Cloud project ID, prompted for when deploying to Google Cloud")
+	cmd.Flags().StringVar(&f.region, "gcp-region", "", "Google Cloud region, prompted for when deploying to Google Cloud")
+	cmd.Flags().StringVar(&f.repository, "gcp-repository", "", "Artifact Registry repository (default: the project name)")
+}
+
+// googleCloud returns the Google Cloud project to deploy to. For Google
+// Cloud targets, the project ID and region are prompted for unless set by
+// flags; for the others, they are only used when set.
+func (f deployFlags) googleCloud() (generator.GoogleCloudOptions, error) {
+	gcp := generator.GoogleCloudOptions{ProjectID: f.project, Region: f.region, Repository: f.repository}
+	if !generator.IsGoogleCloudTarget(f.target) {
+		return gcp, nil
+	}
+
+	// Prompt for the project the service and its images live in
+	if gcp.ProjectID == "" {
+		prompt := promptui.Prompt{Label: "Google Cloud project ID"}
+		projectID, err := prompt.Run()
+		if err != nil {
+			return generator.GoogleCloudOptions{}, err
+		}
+		gcp.ProjectID = projectID
+	}
+	if gcp.Region == "" {
+		prompt := promptui.Prompt{Label: "Google Cloud region", Default: "us-central1"}
+		region, err := prompt.Run()
+		if err != nil {
+			return generator.GoogleCloudOptions{}, err
+		}
+		gcp.Region = region
+	}
+	return gcp, nil
+}
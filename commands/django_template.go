@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// CreateDjangoSkeletonCmd defines a Cobra command to generate a Django skeleton project.
+// It prompts the user for a project name, testing framework, Docker support and dependency manager,
+// and generates a project with an items app exposed through the admin and a REST API.
+func CreateDjangoSkeletonCmd() *cobra.Command {
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-django-skeleton",
+		Short: "Create a Django project with an items app, admin, REST API, per-environment settings, and tests",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Prompt the user to input the project name
+			prompt := promptui.Prompt{
+				Label: "Project Name",
+			}
+			projectName, _ := prompt.Run()
+			if err := generator.ValidateDjangoProjectName(projectName); err != nil {
+				fmt.Println("Error choosing the project name:", err)
+				return
+			}
+
+			// Prompt for testing framework
+			testPrompt := promptui.Select{
+				Label: "Choose a testing framework",
+				Items: []string{"unittest", "pytest", "None"},
+			}
+			_, testingFramework, _ := testPrompt.Run()
+
+			// Prompt the user to decide if they want a Dockerfile
+			dockerPrompt := promptui.Select{
+				Label: "Do you want to include Docker?",
+				Items: []string{"Yes", "No"},
+			}
+			_, useDocker, _ := dockerPrompt.Run()
+
+			// Prompt for dependency manager
+			dependencyPrompt := promptui.Select{
+				Label: "Choose a dependency manager",
+				Items: generator.PythonDependencyManagers,
+			}
+			_, dependencyManager, _ := dependencyPrompt.Run()
+
+			// Generate the project in the current directory
+			err = generator.CreateDjangoSkeleton(cmd.Context(), generator.DjangoOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+					Git:    git,
+					CI:     ci,
+				},
+				TestingFramework:  testingFramework,
+				Docker:            useDocker == "Yes",
+				DependencyManager: dependencyManager,
+			})
+			if err != nil {
+				fmt.Println("Error creating Django project:", err)
+				return
+			}
+		},
+	}
+
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+	return cmd
+}
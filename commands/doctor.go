@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"infocusp-projects/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// DoctorCmd defines a Cobra command that checks the external tools used by the
+// generators and the projects they create, and reports which stacks can be generated.
+func DoctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the installed toolchain and which stacks can be generated",
+		Run: func(cmd *cobra.Command, args []string) {
+			report := generator.Doctor(cmd.Context())
+
+			// List every tool with its version, or why it cannot be used
+			fmt.Println("Tools:")
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, check := range report.Tools {
+				if check.Err != nil {
+					fmt.Fprintf(w, "  ✘ %s\t%s\n", check.Tool.Name, check.Err)
+					continue
+				}
+				fmt.Fprintf(w, "  ✔ %s\t%s\t%s\n", check.Tool.Name, check.Version, check.Path)
+			}
+			w.Flush()
+
+			// Summarize what each create command can do with these tools
+			fmt.Println("\nStacks:")
+			for _, stack := range report.Stacks {
+				if !stack.Usable() {
+					fmt.Printf("  ✘ %s (infocusp %s): missing %s\n", stack.Stack, stack.Command, strings.Join(stack.Missing, ", "))
+					continue
+				}
+				fmt.Printf("  ✔ %s (infocusp %s)", stack.Stack, stack.Command)
+				if len(stack.Options) > 0 {
+					fmt.Printf(" with %s", strings.Join(stack.Options, ", "))
+				}
+				fmt.Println()
+				if len(stack.Warnings) > 0 {
+					fmt.Printf("      to run the project, also install %s\n", strings.Join(stack.Warnings, ", "))
+				}
+			}
+		},
+	}
+}
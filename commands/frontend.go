@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// CreateFrontendCmd defines a Cobra command to generate a frontend app.
+// It prompts the user for a project name, React, Vue, SvelteKit or Angular, TypeScript,
+// Tailwind CSS, linting, unit and end-to-end testing and the package manager, and applies
+// each option with the tooling of the chosen framework.
+func CreateFrontendCmd() *cobra.Command {
+	var packageManagerName string
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-frontend",
+		Short: "Create a React, Vue, SvelteKit or Angular app with TypeScript, Tailwind, ESLint, and unit and e2e tests",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Reject an unknown --package-manager before prompting for anything
+			if err := checkPackageManagerFlag(packageManagerName); err != nil {
+				fmt.Println("Error selecting package manager:", err)
+				return
+			}
+
+			// Prompt the user to input the project name
+			prompt := promptui.Prompt{
+				Label: "Project Name",
+			}
+			projectName, _ := prompt.Run()
+
+			// Prompt for framework
+			frameworkPrompt := promptui.Select{
+				Label: "Choose a framework",
+				Items: generator.FrontendFrameworks,
+			}
+			_, framework, _ := frameworkPrompt.Run()
+
+			// Angular apps are always written in TypeScript
+			useTypeScript := "Yes"
+			if framework != "Angular" {
+				tsPrompt := promptui.Select{
+					Label: "Do you want to use TypeScript?",
+					Items: []string{"Yes", "No"},
+				}
+				_, useTypeScript, _ = tsPrompt.Run()
+			}
+
+			// Prompt the user to decide if they want to include Tailwind CSS
+			tailwindPrompt := promptui.Select{
+				Label: "Do you want to include Tailwind CSS?",
+				Items: []string{"Yes", "No"},
+			}
+			_, useTailwind, _ := tailwindPrompt.Run()
+
+			// Prompt the user to decide if they want to include ESLint
+			lintPrompt := promptui.Select{
+				Label: "Do you want to include Linting (ESLint)?",
+				Items: []string{"Yes", "No"},
+			}
+			_, useLinting, _ := lintPrompt.Run()
+
+			// Prompt the user to decide if they want unit tests
+			unitRunner := "Vitest"
+			if framework == "Angular" {
+				unitRunner = "Karma and Jasmine"
+			}
+			unitPrompt := promptui.Select{
+				Label: "Do you want unit tests (" + unitRunner + ")?",
+				Items: []string{"Yes", "No"},
+			}
+			_, useUnitTests, _ := unitPrompt.Run()
+
+			// Prompt for end-to-end testing tool
+			e2ePrompt := promptui.Select{
+				Label: "Choose an end-to-end testing tool",
+				Items: generator.FrontendE2ETools,
+			}
+			_, e2eTesting, _ := e2ePrompt.Run()
+
+			// Use the package manager from the flag, or prompt with the detected one preselected
+			packageManager, err := PromptPackageManager(packageManagerName)
+			if err != nil {
+				fmt.Println("Error selecting package manager:", err)
+				return
+			}
+
+			// Generate the project in the current directory
+			err = generator.CreateFrontend(cmd.Context(), generator.FrontendOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdin:  os.Stdin,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+					Git:    git,
+					CI:     ci,
+				},
+				Framework:      framework,
+				TypeScript:     useTypeScript == "Yes",
+				Tailwind:       useTailwind == "Yes",
+				Linting:        useLinting == "Yes",
+				UnitTests:      useUnitTests == "Yes",
+				E2ETesting:     e2eTesting,
+				PackageManager: packageManager,
+			})
+			if err != nil {
+				fmt.Println("Error creating frontend:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&packageManagerName, "package-manager", "", "package manager to use ("+strings.Join(generator.PackageManagerNames(), ", ")+")")
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+	return cmd
+}
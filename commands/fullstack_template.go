@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// CreateFullstackCmd defines a Cobra command to generate a full-stack project with a
+// React frontend in frontend/ and a FastAPI backend in backend/.
+//
+// The command prompts for the project name, the same options as create-react-skeleton
+// for the frontend and the testing framework of the backend. The generated project is
+// wired together with a development proxy, CORS middleware and a docker-compose file.
+func CreateFullstackCmd() *cobra.Command {
+	var packageManagerName string
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-fullstack",
+		Short: "Create a React frontend and FastAPI backend wired together in one repository",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Reject an unknown --package-manager before prompting for anything
+			if err := checkPackageManagerFlag(packageManagerName); err != nil {
+				fmt.Println("Error selecting package manager:", err)
+				return
+			}
+
+			// Prompt the user for the project name
+			prompt := promptui.Prompt{Label: "Project Name"}
+			projectName, _ := prompt.Run()
+
+			// Prompt for the frontend options
+			fmt.Println("Frontend (React) options:")
+			frontend, err := PromptReactOptions(packageManagerName)
+			if err != nil {
+				fmt.Println("Error selecting React options:", err)
+				return
+			}
+
+			// Prompt for the backend testing framework
+			fmt.Println("Backend (FastAPI) options:")
+			testPrompt := promptui.Select{
+				Label: "Choose a testing framework",
+				Items: []string{"unittest", "pytest", "None"},
+			}
+			_, testingFramework, _ := testPrompt.Run()
+
+			// Generate the project in the current directory
+			err = generator.CreateFullstack(cmd.Context(), generator.FullstackOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdin:  os.Stdin,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+					Git:    git,
+					CI:     ci,
+				},
+				Frontend: frontend,
+				Backend: generator.FastAPIOptions{
+					TestingFramework: testingFramework,
+				},
+			})
+			if err != nil {
+				fmt.Println("Error creating full-stack project:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&packageManagerName, "package-manager", "", "package manager to use for the frontend ("+strings.Join(generator.PackageManagerNames(), ", ")+")")
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+
+	return cmd
+}
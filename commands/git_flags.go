@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"infocusp-projects/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// gitFlags holds the flags that put a generated project under version control.
+type gitFlags struct {
+	init    bool
+	branch  string
+	author  string
+	message string
+	remote  string
+}
+
+// register adds the git flags to cmd.
+func (f *gitFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.init, "git", false, "Initialize a git repository with an initial commit")
+	cmd.Flags().StringVar(&f.branch, "git-branch", "main", "Default branch of the git repository")
+	cmd.Flags().StringVar(&f.author, "git-author", "", `Author of the initial commit as "Name <email>" (default from git config)`)
+	cmd.Flags().StringVar(&f.message, "git-message", "Initial commit", "Message of the initial commit")
+	cmd.Flags().StringVar(&f.remote, "remote", "", "Add this URL as origin and push the initial commit to it (implies --git)")
+}
+
+// options converts the flags to generator.GitOptions.
+func (f gitFlags) options() (generator.GitOptions, error) {
+	git := generator.GitOptions{
+		Init:          f.init || f.remote != "",
+		DefaultBranch: f.branch,
+		Message:       f.message,
+		Remote:        f.remote,
+	}
+	if f.author != "" {
+		var err error
+		git.AuthorName, git.AuthorEmail, err = generator.ParseGitAuthor(f.author)
+		if err != nil {
+			return generator.GitOptions{}, err
+		}
+	}
+	return git, nil
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// CreateGoServiceCmd defines a Cobra command to generate a Go microservice.
+// It prompts the user for a project name, module path and router, and generates
+// item handlers, configuration, logging, graceful shutdown, tests, a Makefile and a Dockerfile.
+func CreateGoServiceCmd() *cobra.Command {
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-go-service",
+		Short: "Create a Go HTTP service with item handlers, tests, a Makefile, and a distroless Dockerfile",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Prompt the user to input the project name
+			prompt := promptui.Prompt{
+				Label: "Project Name",
+			}
+			projectName, _ := prompt.Run()
+
+			// Prompt for the module path, defaulting to the project name
+			modulePrompt := promptui.Prompt{
+				Label: "Module Path (e.g. github.com/acme/orders, empty for the project name)",
+			}
+			module, _ := modulePrompt.Run()
+
+			// Prompt for router
+			routerPrompt := promptui.Select{
+				Label: "Choose a router",
+				Items: generator.GoRouters,
+			}
+			_, router, _ := routerPrompt.Run()
+
+			// Generate the project in the current directory
+			err = generator.CreateGoService(cmd.Context(), generator.GoServiceOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+					Git:    git,
+					CI:     ci,
+				},
+				Module: module,
+				Router: router,
+			})
+			if err != nil {
+				fmt.Println("Error creating Go service:", err)
+				return
+			}
+		},
+	}
+
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+	return cmd
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// CreateLLMAppCmd defines a Cobra command to generate an LLM chat application.
+// It prompts the user for a project name, the model server and whether to add a React chat
+// frontend, and generates a FastAPI backend with streaming chat endpoints, prompt templates,
+// conversation memory and tests run against a stub model server.
+func CreateLLMAppCmd() *cobra.Command {
+	var packageManagerName string
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-llm-app",
+		Short: "Create a chat app with a streaming FastAPI backend for Ollama or OpenAI-compatible models and an optional React frontend",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Reject an unknown --package-manager before prompting for anything
+			if err := checkPackageManagerFlag(packageManagerName); err != nil {
+				fmt.Println("Error selecting package manager:", err)
+				return
+			}
+
+			// Prompt the user to input the project name
+			prompt := promptui.Prompt{
+				Label: "Project Name",
+			}
+			projectName, _ := prompt.Run()
+
+			// Prompt for the model server
+			providerPrompt := promptui.Select{
+				Label: "Choose a model server",
+				Items: generator.LLMProviders,
+			}
+			_, provider, _ := providerPrompt.Run()
+
+			// Prompt the user to decide if they want a chat frontend
+			frontendPrompt := promptui.Select{
+				Label: "Do you want to include a React chat frontend?",
+				Items: []string{"Yes", "No"},
+			}
+			_, useFrontend, _ := frontendPrompt.Run()
+
+			// Use the package manager from the flag, or prompt with the detected one preselected
+			var packageManager generator.PackageManager
+			if useFrontend == "Yes" {
+				packageManager, err = PromptPackageManager(packageManagerName)
+				if err != nil {
+					fmt.Println("Error selecting package manager:", err)
+					return
+				}
+			}
+
+			// Generate the project in the current directory
+			err = generator.CreateLLMApp(cmd.Context(), generator.LLMOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdin:  os.Stdin,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+					Git:    git,
+					CI:     ci,
+				},
+				Provider:       provider,
+				Frontend:       useFrontend == "Yes",
+				PackageManager: packageManager,
+			})
+			if err != nil {
+				fmt.Println("Error creating LLM app:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&packageManagerName, "package-manager", "", "package manager of the frontend ("+strings.Join(generator.PackageManagerNames(), ", ")+")")
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+	return cmd
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// CreateMLProjectCmd defines a Cobra command to generate a machine learning project.
+// It prompts the user for a project name, a framework, an experiment tracker and DVC support,
+// and generates a Cookiecutter Data Science layout with a config-driven training entrypoint and tests.
+func CreateMLProjectCmd() *cobra.Command {
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-ml-project",
+		Short: "Create a machine learning project with data, features and models modules, config-driven training, tracking, and DVC",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Prompt the user to input the project name
+			prompt := promptui.Prompt{
+				Label: "Project Name",
+			}
+			projectName, _ := prompt.Run()
+
+			// Prompt for framework
+			frameworkPrompt := promptui.Select{
+				Label: "Choose a framework",
+				Items: generator.MLFrameworks,
+			}
+			_, framework, _ := frameworkPrompt.Run()
+
+			// Prompt for experiment tracking
+			trackingPrompt := promptui.Select{
+				Label: "Choose an experiment tracker",
+				Items: generator.MLTrackers,
+			}
+			_, tracking, _ := trackingPrompt.Run()
+
+			// Prompt the user to decide if they want DVC
+			dvcPrompt := promptui.Select{
+				Label: "Do you want to version data and models with DVC?",
+				Items: []string{"Yes", "No"},
+			}
+			_, useDVC, _ := dvcPrompt.Run()
+
+			// Generate the project in the current directory
+			err = generator.CreateMLProject(cmd.Context(), generator.MLOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+					Git:    git,
+					CI:     ci,
+				},
+				Framework: framework,
+				Tracking:  tracking,
+				DVC:       useDVC == "Yes",
+			})
+			if err != nil {
+				fmt.Println("Error creating machine learning project:", err)
+				return
+			}
+		},
+	}
+
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+	return cmd
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// CreateNodeServiceCmd defines a Cobra command to generate a Node.js backend service.
+// It prompts the user for a project name, Express or NestJS, TypeScript, linting, a testing
+// framework, Docker support and the package manager, and generates an Item CRUD example
+// with request validation and tests.
+func CreateNodeServiceCmd() *cobra.Command {
+	var packageManagerName string
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-node-service",
+		Short: "Create an Express or NestJS service with an Item CRUD example, validation, tests, and Docker",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Reject an unknown --package-manager before prompting for anything
+			if err := checkPackageManagerFlag(packageManagerName); err != nil {
+				fmt.Println("Error selecting package manager:", err)
+				return
+			}
+
+			// Prompt the user to input the project name
+			prompt := promptui.Prompt{
+				Label: "Project Name",
+			}
+			projectName, _ := prompt.Run()
+
+			// Prompt for framework
+			frameworkPrompt := promptui.Select{
+				Label: "Choose a framework",
+				Items: generator.NodeFrameworks,
+			}
+			_, framework, _ := frameworkPrompt.Run()
+
+			// NestJS services are always written in TypeScript and tested with Jest
+			useTypeScript := "Yes"
+			testingFrameworks := []string{"Jest", "None"}
+			if framework == "Express" {
+				tsPrompt := promptui.Select{
+					Label: "Do you want to use TypeScript?",
+					Items: []string{"Yes", "No"},
+				}
+				_, useTypeScript, _ = tsPrompt.Run()
+				testingFrameworks = []string{"Jest", "Mocha", "None"}
+			}
+
+			// Prompt the user to decide if they want to include ESLint
+			lintPrompt := promptui.Select{
+				Label: "Do you want to include Linting (ESLint)?",
+				Items: []string{"Yes", "No"},
+			}
+			_, useLinting, _ := lintPrompt.Run()
+
+			// Prompt for testing framework
+			testPrompt := promptui.Select{
+				Label: "Choose a testing framework",
+				Items: testingFrameworks,
+			}
+			_, testingFramework, _ := testPrompt.Run()
+
+			// Prompt the user to decide if they want a Dockerfile
+			dockerPrompt := promptui.Select{
+				Label: "Do you want to include Docker?",
+				Items: []string{"Yes", "No"},
+			}
+			_, useDocker, _ := dockerPrompt.Run()
+
+			// Use the package manager from the flag, or prompt with the detected one preselected
+			packageManager, err := PromptPackageManager(packageManagerName)
+			if err != nil {
+				fmt.Println("Error selecting package manager:", err)
+				return
+			}
+
+			// Generate the project in the current directory
+			err = generator.CreateNodeService(cmd.Context(), generator.NodeServiceOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdin:  os.Stdin,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+					Git:    git,
+					CI:     ci,
+				},
+				Framework:        framework,
+				TypeScript:       useTypeScript == "Yes",
+				Linting:          useLinting == "Yes",
+				TestingFramework: testingFramework,
+				Docker:           useDocker == "Yes",
+				PackageManager:   packageManager,
+			})
+			if err != nil {
+				fmt.Println("Error creating Node.js service:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&packageManagerName, "package-manager", "", "package manager to use ("+strings.Join(generator.PackageManagerNames(), ", ")+")")
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+	return cmd
+}
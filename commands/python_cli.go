@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"infocusp-projects/generator"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// CreatePythonCLICmd defines a Cobra command to generate a Python command-line tool.
+// It prompts the user for a project name, Typer or Click and a testing framework, and
+// generates a src-layout package with subcommand examples and a console script.
+func CreatePythonCLICmd() *cobra.Command {
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-python-cli",
+		Short: "Create a src-layout Python CLI package with Typer or Click, a console script, and CliRunner tests",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Prompt the user to input the project name
+			prompt := promptui.Prompt{
+				Label: "Project Name",
+			}
+			projectName, _ := prompt.Run()
+
+			// Prompt for framework
+			frameworkPrompt := promptui.Select{
+				Label: "Choose a CLI framework",
+				Items: generator.PythonCLIFrameworks,
+			}
+			_, framework, _ := frameworkPrompt.Run()
+
+			// Prompt for testing framework
+			testPrompt := promptui.Select{
+				Label: "Choose a testing framework",
+				Items: []string{"unittest", "pytest", "None"},
+			}
+			_, testingFramework, _ := testPrompt.Run()
+
+			// Generate the project in the current directory
+			err = generator.CreatePythonCLI(cmd.Context(), generator.PythonCLIOptions{
+				Options: generator.Options{
+					Name:   projectName,
+					Stdout: os.Stdout,
+					Stderr: os.Stderr,
+					Git:    git,
+					CI:     ci,
+				},
+				Framework:        framework,
+				TestingFramework: testingFramework,
+			})
+			if err != nil {
+				fmt.Println("Error creating Python CLI:", err)
+				return
+			}
+		},
+	}
+
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+	return cmd
+}
@@ -3,125 +3,208 @@ package commands
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 
+	"infocusp-projects/generator"
+
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
 // CreateReactAppCmd defines a Cobra command to generate a React application
-// with options for Tailwind CSS, ESLint, TypeScript, and a testing framework.
+// with options for Tailwind CSS, ESLint, TypeScript, a testing framework,
+// routing, state management, data fetching, a component library and Storybook.
 //
 // The command prompts the user for a project name and additional configurations
-// such as whether to include Tailwind CSS, TypeScript, linting, and which
-// testing framework to use.
+// such as whether to include Tailwind CSS, TypeScript, linting, which
+// testing framework, router, store, data-fetching and component library to use and
+// which package manager to install with.
 //
 // Returns:
-//   *cobra.Command: A Cobra command object to run the React project generator.
+//
+//	*cobra.Command: A Cobra command object to run the React project generator.
 func CreateReactAppCmd() *cobra.Command {
-    return &cobra.Command{
-        Use:   "create-react-skeleton",
-        Short: "Create a React app with custom options",
-        Run: func(cmd *cobra.Command, args []string) {
-            var projectName, useTailwind, useLinting, testingFramework, useTypeScript string
-
-            // Prompt the user for the project name
-            prompt := promptui.Prompt{Label: "Project Name"}
-            projectName, _ = prompt.Run()
-
-            // Prompt the user to decide if they want to include Tailwind CSS
-            tailwindPrompt := promptui.Select{
-                Label: "Do you want to include Tailwind CSS?",
-                Items: []string{"Yes", "No"},
-            }
-            _, useTailwind, _ = tailwindPrompt.Run()
-
-            // Prompt the user to decide if they want to include ESLint
-            lintPrompt := promptui.Select{
-                Label: "Do you want to include Linting (ESLint)?",
-                Items: []string{"Yes", "No"},
-            }
-            _, useLinting, _ = lintPrompt.Run()
-
-            // Prompt the user to select a testing framework
-            testPrompt := promptui.Select{
-                Label: "Choose a testing framework",
-                Items: []string{"Jest", "Mocha", "None"},
-            }
-            _, testingFramework, _ = testPrompt.Run()
-
-            // Prompt the user to decide if they want to use TypeScript
-            tsPrompt := promptui.Select{
-                Label: "Do you want to use TypeScript?",
-                Items: []string{"Yes", "No"},
-            }
-            _, useTypeScript, _ = tsPrompt.Run()
-
-            // Call the function to handle React project setup with the given user input
-            CreateReactApp(projectName, useTailwind, useLinting, testingFramework, useTypeScript)
-        },
-    }
+	var packageManagerName string
+	var gitOptions gitFlags
+	var ci string
+
+	cmd := &cobra.Command{
+		Use:   "create-react-skeleton",
+		Short: "Create a React app with custom options",
+		Run: func(cmd *cobra.Command, args []string) {
+			git, err := gitOptions.options()
+			if err != nil {
+				fmt.Println("Error parsing git options:", err)
+				return
+			}
+
+			// Reject an unknown --package-manager before prompting for anything
+			if err := checkPackageManagerFlag(packageManagerName); err != nil {
+				fmt.Println("Error selecting package manager:", err)
+				return
+			}
+
+			// Prompt the user for the project name
+			prompt := promptui.Prompt{Label: "Project Name"}
+			projectName, _ := prompt.Run()
+
+			// Prompt for the remaining React options
+			reactOptions, err := PromptReactOptions(packageManagerName)
+			if err != nil {
+				fmt.Println("Error selecting React options:", err)
+				return
+			}
+			reactOptions.Options = generator.Options{
+				Name:   projectName,
+				Stdin:  os.Stdin,
+				Stdout: os.Stdout,
+				Stderr: os.Stderr,
+				Git:    git,
+				CI:     ci,
+			}
+
+			// Generate the project in the current directory with the given user input
+			err = generator.CreateReactApp(cmd.Context(), reactOptions)
+			if err != nil {
+				fmt.Println("Error creating React project:", err)
+				return
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&packageManagerName, "package-manager", "", "package manager to use ("+strings.Join(generator.PackageManagerNames(), ", ")+")")
+	gitOptions.register(cmd)
+	addCIFlag(cmd, &ci)
+
+	return cmd
 }
 
-// CreateReactApp sets up a React project using the given configurations.
-// It supports the following optional customizations:
-// - Tailwind CSS integration
-// - ESLint setup for linting
-// - Testing frameworks (Jest or Mocha)
-// - TypeScript support
-//
-// Parameters:
-//   projectName (string): The name of the React project to be created.
-//   useTailwind (string): "Yes" to include Tailwind CSS, "No" otherwise.
-//   useLinting (string): "Yes" to include ESLint for linting, "No" otherwise.
-//   testingFramework (string): The chosen testing framework ("Jest", "Mocha", or "None").
-//   useTypeScript (string): "Yes" to use TypeScript, "No" otherwise.
-//
-// The function uses `npx create-react-app` to initialize the React project,
-// and conditionally installs and configures Tailwind CSS, ESLint, and the
-// selected testing framework based on the user's inputs.
-func CreateReactApp(projectName, useTailwind, useLinting, testingFramework, useTypeScript string) {
-    var createAppCmd *exec.Cmd
-
-    // Determine whether to create the React app with or without TypeScript
-    if strings.ToLower(useTypeScript) == "yes" {
-        createAppCmd = exec.Command("npx", "create-react-app", projectName, "--template", "typescript")
-    } else {
-        createAppCmd = exec.Command("npx", "create-react-app", projectName)
-    }
-
-    // Run the command to create the React project
-    createAppCmd.Stdout = os.Stdout
-    createAppCmd.Stderr = os.Stderr
-    createAppCmd.Run()
-
-    // Change directory to the newly created project
-    os.Chdir(projectName)
-
-    // If the user selected Tailwind CSS, install and configure it
-    if strings.ToLower(useTailwind) == "yes" {
-        fmt.Println("Installing Tailwind CSS...")
-        exec.Command("npm", "install", "-D", "tailwindcss", "postcss", "autoprefixer").Run()
-        exec.Command("npx", "tailwindcss", "init").Run()
-    }
-
-    // If the user selected ESLint, set up linting
-    if strings.ToLower(useLinting) == "yes" {
-        fmt.Println("Setting up ESLint...")
-        exec.Command("npm", "install", "-D", "eslint").Run()
-        exec.Command("npx", "eslint", "--init").Run()
-    }
-
-    // Install the selected testing framework (Jest or Mocha)
-    if testingFramework == "Jest" {
-        fmt.Println("Setting up Jest...")
-        exec.Command("npm", "install", "--save-dev", "jest").Run()
-    } else if testingFramework == "Mocha" {
-        fmt.Println("Setting up Mocha...")
-        exec.Command("npm", "install", "--save-dev", "mocha").Run()
-    }
-
-    // Output a message indicating successful project creation
-    fmt.Printf("Project '%s' created successfully!\n", projectName)
+// PromptReactOptions prompts for every React option except the project name,
+// which callers fill in along with the rest of generator.Options. The package
+// manager comes from packageManagerName when it is set.
+func PromptReactOptions(packageManagerName string) (generator.ReactOptions, error) {
+	var useTailwind, useLinting, testingFramework, useTypeScript string
+	var router, stateManagement, dataFetching, uiLibrary, useStorybook string
+
+	// Prompt the user to decide if they want to include Tailwind CSS
+	tailwindPrompt := promptui.Select{
+		Label: "Do you want to include Tailwind CSS?",
+		Items: []string{"Yes", "No"},
+	}
+	_, useTailwind, _ = tailwindPrompt.Run()
+
+	// Prompt the user to decide if they want to include ESLint
+	lintPrompt := promptui.Select{
+		Label: "Do you want to include Linting (ESLint)?",
+		Items: []string{"Yes", "No"},
+	}
+	_, useLinting, _ = lintPrompt.Run()
+
+	// Prompt the user to select a testing framework
+	testPrompt := promptui.Select{
+		Label: "Choose a testing framework",
+		Items: []string{"Jest", "Mocha", "None"},
+	}
+	_, testingFramework, _ = testPrompt.Run()
+
+	// Prompt the user to decide if they want to use TypeScript
+	tsPrompt := promptui.Select{
+		Label: "Do you want to use TypeScript?",
+		Items: []string{"Yes", "No"},
+	}
+	_, useTypeScript, _ = tsPrompt.Run()
+
+	// Prompt the user to select a router
+	routerPrompt := promptui.Select{
+		Label: "Choose a router",
+		Items: []string{"React Router", "TanStack Router", "None"},
+	}
+	_, router, _ = routerPrompt.Run()
+
+	// Prompt the user to select a state management library
+	statePrompt := promptui.Select{
+		Label: "Choose a state management library",
+		Items: []string{"Redux Toolkit", "Zustand", "None"},
+	}
+	_, stateManagement, _ = statePrompt.Run()
+
+	// Prompt the user to select a data-fetching library
+	dataPrompt := promptui.Select{
+		Label: "Choose a data-fetching library",
+		Items: []string{"TanStack Query", "SWR", "None"},
+	}
+	_, dataFetching, _ = dataPrompt.Run()
+
+	// Prompt the user to select a component library
+	uiPrompt := promptui.Select{
+		Label: "Choose a component library (shadcn/ui needs Tailwind CSS and TypeScript)",
+		Items: []string{"MUI", "shadcn/ui", "Chakra", "None"},
+	}
+	_, uiLibrary, _ = uiPrompt.Run()
+
+	// Prompt the user to decide if they want to include Storybook
+	storybookPrompt := promptui.Select{
+		Label: "Do you want to include Storybook?",
+		Items: []string{"Yes", "No"},
+	}
+	_, useStorybook, _ = storybookPrompt.Run()
+
+	// Use the package manager from the flag, or prompt with the detected one preselected
+	packageManager, err := PromptPackageManager(packageManagerName)
+	if err != nil {
+		return generator.ReactOptions{}, err
+	}
+
+	return generator.ReactOptions{
+		Tailwind:         strings.ToLower(useTailwind) == "yes",
+		Linting:          strings.ToLower(useLinting) == "yes",
+		TestingFramework: testingFramework,
+		TypeScript:       strings.ToLower(useTypeScript) == "yes",
+		Router:           router,
+		StateManagement:  stateManagement,
+		DataFetching:     dataFetching,
+		UILibrary:        uiLibrary,
+		Storybook:        strings.ToLower(useStorybook) == "yes",
+		PackageManager:   packageManager,
+	}, nil
+}
+
+// checkPackageManagerFlag checks the --package-manager flag of a command, so
+// an unsupported value fails before the user answers any prompt. An empty name
+// is valid: the package manager is then prompted for.
+func checkPackageManagerFlag(name string) error {
+	if name == "" {
+		return nil
+	}
+	_, err := generator.LookupPackageManager(name)
+	return err
+}
+
+// PromptPackageManager resolves the package manager for a new JavaScript project.
+// When name is set (e.g. from a flag) it is used as is; otherwise the user is
+// prompted with the package manager detected from the current directory preselected.
+func PromptPackageManager(name string) (generator.PackageManager, error) {
+	if name != "" {
+		return generator.LookupPackageManager(name)
+	}
+
+	detected := generator.DetectPackageManager(".")
+	names := generator.PackageManagerNames()
+	cursor := 0
+	for i, n := range names {
+		if n == detected.Name {
+			cursor = i
+		}
+	}
+
+	pmPrompt := promptui.Select{
+		Label:     "Choose a package manager",
+		Items:     names,
+		CursorPos: cursor,
+	}
+	_, selected, err := pmPrompt.Run()
+	if err != nil {
+		return generator.PackageManager{}, err
+	}
+	return generator.LookupPackageManager(selected)
 }
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"infocusp-projects/generator"
+
+	"github.com/spf13/cobra"
+)
+
+// UpgradeCmd defines a Cobra command that regenerates a project against the
+// current templates, using the answers recorded when it was created, and
+// 3-way merges the template changes into the user's files.
+func UpgradeCmd() *cobra.Command {
+	var projectDir string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Merge improvements of the templates into a generated project",
+		Long: `Merge improvements of the templates into a generated project.
+
+The project is regenerated with the answers recorded in ` + generator.ManifestFile + ` when it
+was created, and the differences between the original render and the new one
+are merged into your files. Where you changed the same lines as the templates,
+both versions are kept between <<<<<<< and >>>>>>> conflict markers.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			report, err := generator.Upgrade(cmd.Context(), generator.UpgradeOptions{
+				ProjectDir: projectDir,
+				DryRun:     dryRun,
+				Stdout:     os.Stdout,
+			})
+			if err != nil {
+				fmt.Println("Error upgrading project:", err)
+				return
+			}
+
+			for _, group := range []struct {
+				label string
+				paths []string
+			}{
+				{"updated", report.Updated},
+				{"created", report.Created},
+				{"removed", report.Removed},
+				{"conflict", report.Conflicts},
+				{"skipped", report.Skipped},
+			} {
+				for _, path := range group.paths {
+					fmt.Printf("  %-9s %s\n", group.label, path)
+				}
+			}
+
+			switch {
+			case len(report.Updated)+len(report.Created)+len(report.Removed)+len(report.Conflicts) == 0:
+				fmt.Printf("Project is up to date with template version %d.\n", report.ToVersion)
+			case dryRun:
+				fmt.Println("Dry run, nothing was written.")
+			case len(report.Conflicts) > 0:
+				fmt.Printf("Upgraded with %d conflicting files; resolve the conflict markers in them.\n", len(report.Conflicts))
+			default:
+				fmt.Printf("Upgraded to template version %d.\n", report.ToVersion)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&projectDir, "dir", ".", "Directory of the project to upgrade")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what would change without writing anything")
+	return cmd
+}
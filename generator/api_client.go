@@ -0,0 +1,516 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// apiClientHeader starts every generated client file. Files without it were
+// written by hand and are never overwritten.
+const apiClientHeader = "// Code generated by infocusp add api-client. DO NOT EDIT."
+
+// APIClientOptions configures GenerateAPIClient.
+type APIClientOptions struct {
+	// ProjectDir is the root of a TypeScript React project.
+	ProjectDir string
+
+	// Spec describes the API, either read from an OpenAPI document or
+	// built from resource definitions by ResourcesOpenAPI.
+	Spec *OpenAPISpec
+
+	// Stdout receives progress messages. Nil discards them.
+	Stdout io.Writer
+}
+
+// apiClientData is the data the client and hooks templates are rendered with.
+type apiClientData struct {
+	DataFetching string // "TanStack Query", "SWR" or "" for plain React hooks
+	BaseURL      string // default base URL of the API, e.g. /api behind the development proxy
+	Types        []tsDeclaration
+	Operations   []tsOperation
+	HookTypes    string // types the hooks module imports from the client
+}
+
+// tsDeclaration is an exported interface or type alias for a component schema.
+type tsDeclaration struct {
+	Name       string
+	Doc        string
+	Properties []string // interface members, empty for a type alias
+	Alias      string   // aliased type when the schema is not an object
+}
+
+// tsOperation is one operation as a client function and a React hook.
+type tsOperation struct {
+	Function    string // client function, e.g. getPetById
+	Hook        string // hook, e.g. useGetPetById
+	Doc         string
+	Method      string
+	Query       bool   // whether the operation only reads, and gets a query hook
+	Params      string // client function parameters
+	Args        string // the parameters passed on, in order
+	Vars        string // mutation variables type, empty when there are none
+	VarArgs     string // the variables passed to the client function
+	Result      string // resolved type of the returned promise
+	Path        string // path as a template literal
+	Options     string // request options object, empty when there are none
+	Key         string // query key
+	MutationKey string // key identifying the mutation
+	Tag         string // the operation's tag as a string literal
+	TagKey      string // key prefix shared by the queries of the operation's tag
+}
+
+// tsReservedWords are words that cannot name TypeScript parameters.
+var tsReservedWords = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true,
+	"export": true, "extends": true, "false": true, "finally": true, "for": true, "function": true,
+	"if": true, "import": true, "in": true, "instanceof": true, "new": true, "null": true,
+	"return": true, "super": true, "switch": true, "this": true, "throw": true, "true": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true, "with": true,
+}
+
+// tsReservedTypeNames are the names a schema cannot be declared with: globals
+// the generated modules use and the names they declare themselves. Declaring
+// an interface Error, say, would shadow the class ApiError extends.
+var tsReservedTypeNames = map[string]bool{
+	"ApiError": true, "Array": true, "Error": true, "JSON": true, "MutationResult": true, "Object": true,
+	"Promise": true, "QueryResult": true, "Record": true, "Request": true, "RequestOptions": true,
+	"Response": true, "String": true, "URLSearchParams": true,
+}
+
+// tsTypeName returns the TypeScript name of the component schema called name,
+// suffixed with Schema when it is reserved.
+func tsTypeName(name string) string {
+	typeName := pythonClassName(name)
+	if tsReservedTypeNames[typeName] {
+		typeName += "Schema"
+	}
+	return typeName
+}
+
+// tsIdentifierPattern matches property names that need no quotes.
+var tsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// GenerateAPIClient writes a typed API client, src/api/generated/client.ts,
+// and React hooks calling it, src/api/generated/hooks.ts, into a TypeScript
+// React project. The hooks use the project's data-fetching library, TanStack
+// Query or SWR, and plain React state otherwise.
+//
+// Generation is idempotent: files whose content is unchanged are not
+// rewritten, so it can run whenever the API changes.
+func GenerateAPIClient(ctx context.Context, opts APIClientOptions) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(opts.ProjectDir, "tsconfig.json")); err != nil {
+		return fmt.Errorf("%s is not a TypeScript project: tsconfig.json not found", opts.ProjectDir)
+	}
+
+	dataFetching, err := detectDataFetching(opts.ProjectDir)
+	if err != nil {
+		return err
+	}
+
+	data := newAPIClientData(opts.Spec, dataFetching)
+	if data.BaseURL, err = detectProxyPrefix(opts.ProjectDir); err != nil {
+		return err
+	}
+	files, err := renderTemplates("react/api-client", data)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range files.Paths() {
+		target := filepath.Join(opts.ProjectDir, filepath.FromSlash(path))
+		existing, err := os.ReadFile(target)
+		switch {
+		case err == nil && string(existing) == files[path]:
+			if opts.Stdout != nil {
+				fmt.Fprintf(opts.Stdout, "%s is up to date.\n", path)
+			}
+			continue
+		case err == nil && !strings.HasPrefix(string(existing), apiClientHeader):
+			return fmt.Errorf("%s was not generated by infocusp and would be overwritten", path)
+		case err != nil && !os.IsNotExist(err):
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, []byte(files[path]), 0644); err != nil {
+			return err
+		}
+		if opts.Stdout != nil {
+			fmt.Fprintf(opts.Stdout, "Generated %s.\n", path)
+		}
+	}
+	return nil
+}
+
+// proxyPrefixPattern matches the prefix a development proxy forwards and strips,
+// as create-fullstack writes it in src/setupProxy.js.
+var proxyPrefixPattern = regexp.MustCompile(`pathRewrite: \{ '\^(/[^']*)': '' \}`)
+
+// detectProxyPrefix reports the prefix the project's development proxy
+// mounts the API under, or "" when it has no such proxy.
+func detectProxyPrefix(dir string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "src", "setupProxy.js"))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if match := proxyPrefixPattern.FindSubmatch(content); match != nil {
+		return string(match[1]), nil
+	}
+	return "", nil
+}
+
+// detectDataFetching reports the data-fetching library in the dependencies
+// of the project's package.json, as a React data-fetching option.
+func detectDataFetching(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return "", err
+	}
+	var manifest struct {
+		Dependencies map[string]string `json:"dependencies"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return "", fmt.Errorf("parsing package.json: %w", err)
+	}
+
+	for _, option := range []string{"TanStack Query", "SWR"} {
+		if _, ok := manifest.Dependencies[reactDataFetchingPackages[option][0]]; ok {
+			return option, nil
+		}
+	}
+	return "", nil
+}
+
+// ResourcesOpenAPI describes the CRUD endpoints `infocusp add resource`
+// generates in a FastAPI project for each resource, so a client can be
+// generated for them without an OpenAPI document.
+func ResourcesOpenAPI(resources []Resource) (*OpenAPISpec, error) {
+	spec := &OpenAPISpec{Schemas: map[string]*OpenAPISchema{}}
+
+	for _, resource := range resources {
+		data, err := newResourceTemplateData(resource.Name, resource.Fields)
+		if err != nil {
+			return nil, err
+		}
+
+		model := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+		for _, field := range resource.Fields {
+			model.Properties[field.Name] = fieldSchema(field)
+			model.PropertyNames = append(model.PropertyNames, field.Name)
+			model.Required = append(model.Required, field.Name)
+		}
+		spec.Schemas[data.Name] = model
+		spec.SchemaNames = append(spec.SchemaNames, data.Name)
+
+		ref := &OpenAPISchema{Ref: "#/components/schemas/" + data.Name}
+		id := &OpenAPISchema{Type: "integer"}
+		message := &OpenAPISchema{Type: "string"}
+		entry := objectSchema([]string{data.Snake + "_id", data.Snake}, id, ref)
+		saved := objectSchema([]string{"message", data.Snake + "_id", data.Snake}, message, id, ref)
+		idParam := []OpenAPIParameter{{Name: data.Snake + "_id", In: "path", Required: true, Schema: id}}
+
+		collection := "/" + data.URLPath + "/"
+		member := "/" + data.URLPath + "/{" + data.Snake + "_id}"
+		spec.Operations = append(spec.Operations,
+			OpenAPIOperation{Method: "POST", Path: collection, OperationID: "create" + data.Name, Tag: data.Plural, RequestBody: ref, RequestBodyRequired: true, Status: 200, Response: saved},
+			OpenAPIOperation{Method: "GET", Path: collection, OperationID: "list" + pythonClassName(data.Plural), Tag: data.Plural, Status: 200, Response: objectSchema([]string{data.Plural}, &OpenAPISchema{Type: "array", Items: entry})},
+			OpenAPIOperation{Method: "GET", Path: member, OperationID: "get" + data.Name, Tag: data.Plural, Parameters: idParam, Status: 200, Response: entry},
+			OpenAPIOperation{Method: "PUT", Path: member, OperationID: "update" + data.Name, Tag: data.Plural, Parameters: idParam, RequestBody: ref, RequestBodyRequired: true, Status: 200, Response: saved},
+			OpenAPIOperation{Method: "DELETE", Path: member, OperationID: "delete" + data.Name, Tag: data.Plural, Parameters: idParam, Status: 200, Response: objectSchema([]string{"message", data.Snake + "_id"}, message, id)},
+		)
+	}
+	return spec, nil
+}
+
+// fieldSchema returns the JSON schema of a resource field.
+func fieldSchema(field Field) *OpenAPISchema {
+	switch field.Type {
+	case "int":
+		return &OpenAPISchema{Type: "integer"}
+	case "float":
+		return &OpenAPISchema{Type: "number"}
+	case "bool":
+		return &OpenAPISchema{Type: "boolean"}
+	case "date":
+		return &OpenAPISchema{Type: "string", Format: "date"}
+	case "datetime":
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	}
+	return &OpenAPISchema{Type: "string"}
+}
+
+// objectSchema returns an object schema requiring the named properties.
+func objectSchema(names []string, properties ...*OpenAPISchema) *OpenAPISchema {
+	schema := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}, PropertyNames: names, Required: names}
+	for i, name := range names {
+		schema.Properties[name] = properties[i]
+	}
+	return schema
+}
+
+// newAPIClientData converts the spec to TypeScript declarations and
+// operations.
+func newAPIClientData(spec *OpenAPISpec, dataFetching string) apiClientData {
+	data := apiClientData{DataFetching: dataFetching}
+
+	for _, name := range spec.SchemaNames {
+		schema := spec.Schemas[name]
+		declaration := tsDeclaration{Name: tsTypeName(name), Doc: tsComment(schema.Description)}
+		object := spec.Flatten(schema)
+		if len(object.Properties) == 0 {
+			declaration.Alias = tsType(object)
+		}
+		for _, property := range object.PropertyNames {
+			declaration.Properties = append(declaration.Properties, tsProperty(object, property))
+		}
+		data.Types = append(data.Types, declaration)
+	}
+
+	// Functions cannot take the names the client module declares itself
+	functions := []string{"API_URL", "ApiError", "request"}
+	var signatures []string
+	for _, op := range spec.Operations {
+		operation := newTSOperation(op, functions)
+		functions = append(functions, operation.Function)
+		signatures = append(signatures, operation.Params, operation.Vars)
+		data.Operations = append(data.Operations, operation)
+	}
+
+	// The hooks repeat the parameter types of the client functions
+	var hookTypes []string
+	for _, declaration := range data.Types {
+		if regexp.MustCompile(`\b` + declaration.Name + `\b`).MatchString(strings.Join(signatures, "\n")) {
+			hookTypes = append(hookTypes, declaration.Name)
+		}
+	}
+	data.HookTypes = strings.Join(hookTypes, ", ")
+	return data
+}
+
+// newTSOperation converts an operation to a client function and hook.
+// taken lists the function names already used.
+func newTSOperation(op OpenAPIOperation, taken []string) tsOperation {
+	name := op.OperationID
+	if name == "" {
+		name = strings.ToLower(op.Method) + " " + op.Path
+	}
+	function := uniqueName(tsIdentifier(name), taken)
+
+	operation := tsOperation{
+		Function: function,
+		Hook:     "use" + strings.ToUpper(function[:1]) + function[1:],
+		Doc:      tsComment(op.Summary),
+		Method:   op.Method,
+		Query:    op.Method == "GET",
+		Result:   "void",
+		Tag:      tsString(op.Tag),
+		TagKey:   "[" + tsString(op.Tag) + "]",
+	}
+	if op.Response != nil && op.Status != 204 {
+		operation.Result = tsType(op.Response)
+	}
+
+	type param struct {
+		name, typ string
+		optional  bool
+	}
+	var params []param
+	var used []string
+	paramNames := map[string]string{}
+	var query, headers []string
+	queryRequired, headersRequired := false, false
+
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			name := uniqueName(tsIdentifier(p.Name), used)
+			used = append(used, name)
+			paramNames[p.Name] = name
+			params = append(params, param{name, tsType(p.Schema), false})
+		case "query":
+			query = append(query, tsMember(p.Name, tsType(p.Schema), !p.Required))
+			queryRequired = queryRequired || p.Required
+		case "header":
+			headers = append(headers, tsMember(p.Name, "string", !p.Required))
+			headersRequired = headersRequired || p.Required
+		}
+	}
+
+	var options []string
+	if op.RequestBody != nil {
+		params = append(params, param{uniqueName("body", used), tsType(op.RequestBody), !op.RequestBodyRequired})
+		options = append(options, "body")
+	}
+	if len(query) > 0 {
+		params = append(params, param{uniqueName("query", used), "{ " + strings.Join(query, "; ") + " }", !queryRequired})
+		options = append(options, "query")
+	}
+	if len(headers) > 0 {
+		params = append(params, param{uniqueName("headers", used), "{ " + strings.Join(headers, "; ") + " }", !headersRequired})
+		options = append(options, "headers")
+	}
+	// Optional parameters must follow the required ones
+	sort.SliceStable(params, func(i, j int) bool { return !params[i].optional && params[j].optional })
+
+	var decls, args, vars, varArgs []string
+	for _, p := range params {
+		decls = append(decls, tsMember(p.name, p.typ, p.optional))
+		args = append(args, p.name)
+		vars = append(vars, tsMember(p.name, p.typ, p.optional))
+		varArgs = append(varArgs, "vars."+p.name)
+	}
+	operation.Params = strings.Join(decls, ", ")
+	operation.Args = strings.Join(args, ", ")
+	if len(vars) > 0 {
+		operation.Vars = "{ " + strings.Join(vars, "; ") + " }"
+		operation.VarArgs = strings.Join(varArgs, ", ")
+	}
+	if len(options) > 0 {
+		operation.Options = "{ " + strings.Join(options, ", ") + " }"
+	}
+	operation.Key = "[" + strings.Join(append([]string{tsString(op.Tag), tsString(function)}, args...), ", ") + "]"
+	operation.MutationKey = "[" + tsString(op.Tag) + ", " + tsString(function) + "]"
+
+	operation.Path = "`" + pathParamPattern.ReplaceAllStringFunc(op.Path, func(placeholder string) string {
+		if name, ok := paramNames[placeholder[1:len(placeholder)-1]]; ok {
+			return "${encodeURIComponent(String(" + name + "))}"
+		}
+		return placeholder
+	}) + "`"
+	return operation
+}
+
+// tsType returns the TypeScript type for a schema. Dates are left as the
+// strings they are sent as.
+func tsType(s *OpenAPISchema) string {
+	typ := tsBaseType(s)
+	if s != nil && s.Nullable && typ != "unknown" {
+		return typ + " | null"
+	}
+	return typ
+}
+
+// tsBaseType returns the TypeScript type for a schema, ignoring whether it
+// is nullable.
+func tsBaseType(s *OpenAPISchema) string {
+	switch {
+	case s == nil:
+		return "unknown"
+	case s.Ref != "":
+		return tsTypeName(s.RefName())
+	case len(s.AllOf) > 0:
+		parts := make([]string, len(s.AllOf))
+		for i, part := range s.AllOf {
+			parts[i] = tsGroup(tsType(part))
+		}
+		return strings.Join(parts, " & ")
+	case len(s.Enum) > 0:
+		values := make([]string, len(s.Enum))
+		for i, value := range s.Enum {
+			if text, ok := value.(string); ok {
+				values[i] = tsString(text)
+			} else {
+				values[i] = fmt.Sprint(value)
+			}
+		}
+		return strings.Join(values, " | ")
+	case len(s.Properties) > 0:
+		members := make([]string, len(s.PropertyNames))
+		for i, name := range s.PropertyNames {
+			members[i] = tsProperty(s, name)
+		}
+		return "{ " + strings.Join(members, "; ") + " }"
+	}
+
+	switch s.Type {
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		return tsGroup(tsType(s.Items)) + "[]"
+	case "object":
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+// tsGroup parenthesizes union and intersection types so they can be used
+// as array elements or intersection parts.
+func tsGroup(typ string) string {
+	if strings.Contains(typ, " | ") || strings.Contains(typ, " & ") {
+		return "(" + typ + ")"
+	}
+	return typ
+}
+
+// tsProperty returns the member declaration of an object property.
+func tsProperty(s *OpenAPISchema, name string) string {
+	return tsMember(name, tsType(s.Properties[name]), !s.IsRequired(name))
+}
+
+// tsMember returns "name: type" or "name?: type", quoting the name when it
+// is not an identifier.
+func tsMember(name, typ string, optional bool) string {
+	if !tsIdentifierPattern.MatchString(name) {
+		name = tsString(name)
+	}
+	if optional {
+		name += "?"
+	}
+	return name + ": " + typ
+}
+
+// tsIdentifier converts a name such as "pet_id" or "get /pets/{id}" to a
+// camelCase identifier.
+func tsIdentifier(name string) string {
+	words := strings.Split(pythonIdentifier(name), "_")
+	identifier := ""
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		if identifier == "" {
+			identifier = word
+		} else {
+			identifier += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	if identifier == "" || tsReservedWords[identifier] || (identifier[0] >= '0' && identifier[0] <= '9') {
+		identifier = "_" + identifier
+	}
+	return identifier
+}
+
+// tsString formats text as a single-quoted TypeScript string literal.
+func tsString(text string) string {
+	quoted := strconv.Quote(text)
+	quoted = strings.ReplaceAll(quoted[1:len(quoted)-1], `\"`, `"`)
+	return "'" + strings.ReplaceAll(quoted, "'", `\'`) + "'"
+}
+
+// tsComment returns the first line of text, made safe to place in a block
+// comment.
+func tsComment(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.ReplaceAll(strings.TrimSpace(line), "*/", "* /")
+}
@@ -0,0 +1,137 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newAPIClientProject writes a minimal TypeScript React project, plus the
+// given files, to a temporary directory.
+func newAPIClientProject(t *testing.T, files Files) string {
+	t.Helper()
+	dir := t.TempDir()
+	project := Files{
+		"tsconfig.json": "{}\n",
+		"package.json":  `{"dependencies": {"react": "^18.3.1"}}` + "\n",
+	}
+	for path, content := range files {
+		project[path] = content
+	}
+	if err := project.Write(dir); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// generatedClient generates a client for spec into dir and returns it.
+func generatedClient(t *testing.T, dir string, spec *OpenAPISpec) string {
+	t.Helper()
+	if err := GenerateAPIClient(context.Background(), APIClientOptions{ProjectDir: dir, Spec: spec}); err != nil {
+		t.Fatal(err)
+	}
+	client, err := os.ReadFile(filepath.Join(dir, "src", "api", "generated", "client.ts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(client)
+}
+
+func TestAPIClientBaseURL(t *testing.T) {
+	spec, err := ResourcesOpenAPI([]Resource{{Name: "Order", Fields: []Field{{Name: "total", Type: "float"}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setupProxy, err := templateFS.ReadFile("templates/fullstack/frontend/src/setupProxy.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		files Files
+		want  string
+	}{
+		{name: "standalone", want: "process.env.REACT_APP_API_URL || '';"},
+		{name: "fullstack", files: Files{"src/setupProxy.js": string(setupProxy)}, want: "process.env.REACT_APP_API_URL || '/api';"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := generatedClient(t, newAPIClientProject(t, tt.files), spec)
+			if !strings.Contains(client, tt.want) {
+				t.Errorf("client.ts does not contain %q", tt.want)
+			}
+		})
+	}
+}
+
+// petstoreSpec is an excerpt of the petstore example, whose error responses
+// use a schema called Error.
+const petstoreSpec = `openapi: 3.0.0
+info:
+  title: Swagger Petstore
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    get:
+      operationId: showPetById
+      tags: [pets]
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: Expected response to a valid request
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+        default:
+          description: unexpected error
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Error"
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+        lastError:
+          $ref: "#/components/schemas/Error"
+    Error:
+      type: object
+      required: [code, message]
+      properties:
+        code:
+          type: integer
+        message:
+          type: string
+`
+
+func TestAPIClientRenamesReservedSchemas(t *testing.T) {
+	spec, err := ParseOpenAPI([]byte(petstoreSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := generatedClient(t, newAPIClientProject(t, nil), spec)
+
+	if strings.Contains(client, "interface Error ") {
+		t.Error("client.ts declares an interface Error, shadowing the class ApiError extends")
+	}
+	for _, want := range []string{"export class ApiError extends Error {", "export interface ErrorSchema {", "lastError?: ErrorSchema"} {
+		if !strings.Contains(client, want) {
+			t.Errorf("client.ts does not contain %q:\n%s", want, client)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CIProviders lists the CI services a pipeline can be generated for.
+var CIProviders = []string{"github", "gitlab"}
+
+// ciPythonVersion, ciNodeVersion and ciGoVersion are the runtimes the
+// pipelines test on.
+const (
+	ciPythonVersion = "3.12"
+	ciNodeVersion   = "20"
+	ciGoVersion     = "1.23"
+)
+
+// ciGitLabCaches maps each package manager to the directory, relative to the
+// package, it is told to keep its download cache in on GitLab, which only
+// caches paths inside the project, and the install command doing so.
+var ciGitLabCaches = map[string]struct{ Dir, Install string }{
+	"npm":  {Dir: ".npm", Install: "npm ci --cache .npm --prefer-offline"},
+	"yarn": {Dir: ".yarn-cache", Install: "yarn install --frozen-lockfile --cache-folder .yarn-cache"},
+	"pnpm": {Dir: ".pnpm-store", Install: "pnpm install --frozen-lockfile --store-dir .pnpm-store"},
+	"bun":  {Dir: ".bun-cache", Install: "BUN_INSTALL_CACHE_DIR=.bun-cache bun install --frozen-lockfile"},
+}
+
+// ciJob describes the jobs of one package of a project: a Python or Node.js
+// app at the root, or in a subdirectory of a full-stack repository.
+type ciJob struct {
+	// Prefix is prepended to the job names, e.g. "backend-". Empty for
+	// projects with a single package.
+	Prefix string
+
+	// Dir is the package directory relative to the repository root.
+	Dir string
+
+	Stack          string // "python", "node" or "go"
+	Version        string // of Python, Node.js or Go
+	PackageManager string // Node.js only
+
+	// Install, Lint, Test and Build are shell commands run in Dir. Lint
+	// and Build are empty when the package has no such step.
+	Install string
+	Lint    string
+	Test    string
+	Build   string
+
+	// CacheFile is the lockfile, relative to the repository root, the
+	// dependency cache is keyed on.
+	CacheFile string
+
+	// GitLabInstall and GitLabCache install with the download cache in
+	// GitLabCache, relative to the repository root.
+	GitLabInstall string
+	GitLabCache   string
+
+	// Image names the Docker image built from Dir/Dockerfile, empty when
+	// the package has none.
+	Image string
+}
+
+// ciTemplateData is the data the CI templates are rendered with.
+type ciTemplateData struct {
+	Jobs []ciJob
+
+	// Lint and Docker are set when any job lints or builds an image.
+	Lint   bool
+	Docker bool
+}
+
+// imageNamePattern matches the characters not allowed in Docker image names.
+var imageNamePattern = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// pythonCIJob describes the jobs of the Python package in
+// projectDir/dir, tested with testingFramework.
+func pythonCIJob(projectDir, dir, name, testingFramework string) ciJob {
+	job := ciJob{
+		Dir:           dir,
+		Stack:         "python",
+		Version:       ciPythonVersion,
+		Install:       "pip install -r requirements.txt",
+		CacheFile:     path.Join(dir, "requirements.txt"),
+		GitLabInstall: "pip install -r requirements.txt",
+		GitLabCache:   ".cache/pip",
+	}
+	switch testingFramework {
+	case "pytest":
+		job.Test = "pytest"
+	case "unittest":
+		job.Test = "python -m unittest discover"
+	default:
+		// Without tests, at least check that every module compiles
+		job.Test = "python -m compileall -q app"
+	}
+	job.Image = ciImage(projectDir, dir, name)
+	return job
+}
+
+// nodeCIJob describes the jobs of the Node.js package in projectDir/dir,
+// which run its lint, test and build package scripts. Empty script names
+// skip the step.
+func nodeCIJob(projectDir, dir, name string, pm PackageManager, lint, test, build string) ciJob {
+	lockfile := pm.Lockfiles[0]
+	for _, candidate := range pm.Lockfiles {
+		if _, err := os.Stat(filepath.Join(projectDir, dir, candidate)); err == nil {
+			lockfile = candidate
+			break
+		}
+	}
+
+	job := ciJob{
+		Dir:            dir,
+		Stack:          "node",
+		Version:        ciNodeVersion,
+		PackageManager: pm.Name,
+		Install:        pm.CleanInstall(),
+		CacheFile:      path.Join(dir, lockfile),
+		GitLabInstall:  ciGitLabCaches[pm.Name].Install,
+		GitLabCache:    path.Join(dir, ciGitLabCaches[pm.Name].Dir),
+	}
+	if lint != "" {
+		job.Lint = pm.Run(lint)
+	}
+	if test != "" {
+		job.Test = pm.Run(test)
+	}
+	if build != "" {
+		job.Build = pm.Run(build)
+	}
+	job.Image = ciImage(projectDir, dir, name)
+	return job
+}
+
+// goCIJob describes the jobs of the Go module in projectDir/dir.
+func goCIJob(projectDir, dir, name string) ciJob {
+	// Modules without dependencies have no go.sum
+	cacheFile := path.Join(dir, "go.sum")
+	if _, err := os.Stat(filepath.Join(projectDir, dir, "go.sum")); err != nil {
+		cacheFile = path.Join(dir, "go.mod")
+	}
+
+	job := ciJob{
+		Dir:           dir,
+		Stack:         "go",
+		Version:       ciGoVersion,
+		Install:       "go mod download",
+		Lint:          "go vet ./...",
+		Test:          "go test -race ./...",
+		Build:         "go build ./...",
+		CacheFile:     cacheFile,
+		GitLabInstall: "go mod download",
+		GitLabCache:   path.Join(dir, ".go"),
+	}
+	job.Image = ciImage(projectDir, dir, name)
+	return job
+}
+
+// ciImage returns the Docker image name for the package in projectDir/dir,
+// or "" when it has no Dockerfile.
+func ciImage(projectDir, dir, name string) string {
+	if _, err := os.Stat(filepath.Join(projectDir, dir, "Dockerfile")); err != nil {
+		return ""
+	}
+	return strings.Trim(imageNamePattern.ReplaceAllString(strings.ToLower(name), "-"), "-._")
+}
+
+// writeCI writes the pipeline configuration for o.CI into projectDir, with
+// the jobs of every package of the project.
+func (o Options) writeCI(projectDir string, jobs ...ciJob) error {
+	if o.CI == "" {
+		return nil
+	}
+
+	data := ciTemplateData{Jobs: jobs}
+	for _, job := range jobs {
+		data.Lint = data.Lint || job.Lint != ""
+		data.Docker = data.Docker || job.Image != ""
+	}
+
+	files, err := renderTemplates("ci/"+o.CI, data)
+	if err != nil {
+		return err
+	}
+	o.printf("Writing %s CI configuration...\n", o.CI)
+	return files.Write(projectDir)
+}
+
+// validateCI checks that provider is one of CIProviders, or empty.
+func validateCI(provider string) error {
+	if provider == "" {
+		return nil
+	}
+	for _, p := range CIProviders {
+		if p == provider {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported CI provider %q (choose one of %s)", provider, strings.Join(CIProviders, ", "))
+}
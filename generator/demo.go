@@ -0,0 +1,323 @@
+package generator
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// builtinDemos is the demo catalog shipped with the CLI.
+//
+//go:embed demos.yaml
+var builtinDemos []byte
+
+// Demo is an entry of the demo catalog: a ready-to-run project created from
+// a built-in template or cloned from a git repository.
+type Demo struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+
+	// Template is the create command the demo is generated with, one of
+	// DemoTemplates, and Options its answers, keyed like the answers of
+	// the project manifest.
+	Template string            `yaml:"template,omitempty"`
+	Options  map[string]string `yaml:"options,omitempty"`
+
+	// Repo is the URL of a git repository cloned instead of generating the
+	// demo from a template.
+	Repo string `yaml:"repo,omitempty"`
+
+	// Setup are shell commands run in the project directory once it is
+	// created, such as installing dependencies.
+	Setup []string `yaml:"setup,omitempty"`
+
+	// Instructions tell how to run the demo once it is set up.
+	Instructions string `yaml:"instructions,omitempty"`
+}
+
+// Source describes where the demo comes from, for listings.
+func (d Demo) Source() string {
+	if d.Repo != "" {
+		return d.Repo
+	}
+	return d.Template
+}
+
+// validate checks that the demo has a name and exactly one known source,
+// and that its template accepts each of its options.
+func (d Demo) validate() error {
+	if d.Name == "" {
+		return errors.New("demo without a name")
+	}
+	switch {
+	case d.Template != "" && d.Repo != "":
+		return fmt.Errorf("demo %q sets both a template and a repo", d.Name)
+	case d.Repo != "":
+		return nil
+	case d.Template == "":
+		return fmt.Errorf("demo %q sets neither a template nor a repo", d.Name)
+	}
+	template, ok := demoTemplates[d.Template]
+	if !ok {
+		return fmt.Errorf("demo %q uses unknown template %q (choose one of %s)", d.Name, d.Template, strings.Join(DemoTemplates(), ", "))
+	}
+	for _, key := range slices.Sorted(maps.Keys(d.Options)) {
+		if !slices.Contains(template.Options, key) {
+			return fmt.Errorf("demo %q sets unknown option %q of %s (choose one of %s)", d.Name, key, d.Template, strings.Join(template.Options, ", "))
+		}
+	}
+	return nil
+}
+
+// demoTemplate creates a demo with a create command, from the options it
+// accepts.
+type demoTemplate struct {
+	// Options are the option keys the template accepts.
+	Options []string
+
+	Create func(ctx context.Context, opts Options, options demoTemplateOptions) error
+}
+
+// demoTemplates maps each create command a demo can be generated with to the
+// template creating the project from the demo's options.
+var demoTemplates = map[string]demoTemplate{
+	"create-demo-app": {
+		Options: []string{"framework"},
+		Create: func(ctx context.Context, opts Options, options demoTemplateOptions) error {
+			return CreateDemoApp(ctx, DemoAppOptions{Options: opts, Framework: options["framework"]})
+		},
+	},
+	"create-llm-app": {
+		Options: []string{"provider", "frontend"},
+		Create: func(ctx context.Context, opts Options, options demoTemplateOptions) error {
+			frontend, err := options.bool("frontend")
+			if err != nil {
+				return err
+			}
+			return CreateLLMApp(ctx, LLMOptions{Options: opts, Provider: options["provider"], Frontend: frontend})
+		},
+	},
+	"create-fastapi-skeleton": {
+		Options: []string{"testingFramework", "modelServing"},
+		Create: func(ctx context.Context, opts Options, options demoTemplateOptions) error {
+			modelServing, err := options.bool("modelServing")
+			if err != nil {
+				return err
+			}
+			return CreateFastAPISkeleton(ctx, FastAPIOptions{Options: opts, TestingFramework: options["testingFramework"], ModelServing: modelServing})
+		},
+	},
+	"create-ml-project": {
+		Options: []string{"framework", "tracking", "dvc"},
+		Create: func(ctx context.Context, opts Options, options demoTemplateOptions) error {
+			dvc, err := options.bool("dvc")
+			if err != nil {
+				return err
+			}
+			return CreateMLProject(ctx, MLOptions{Options: opts, Framework: options["framework"], Tracking: options["tracking"], DVC: dvc})
+		},
+	},
+	"create-python-cli": {
+		Options: []string{"framework", "testingFramework"},
+		Create: func(ctx context.Context, opts Options, options demoTemplateOptions) error {
+			return CreatePythonCLI(ctx, PythonCLIOptions{Options: opts, Framework: options["framework"], TestingFramework: options["testingFramework"]})
+		},
+	},
+	"create-go-service": {
+		Options: []string{"module", "router"},
+		Create: func(ctx context.Context, opts Options, options demoTemplateOptions) error {
+			return CreateGoService(ctx, GoServiceOptions{Options: opts, Module: options["module"], Router: options["router"]})
+		},
+	},
+}
+
+// DemoTemplates lists the create commands demos can be generated with.
+func DemoTemplates() []string {
+	names := make([]string, 0, len(demoTemplates))
+	for name := range demoTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// demoTemplateOptions are the options of a demo created from a template.
+type demoTemplateOptions map[string]string
+
+// bool returns the boolean option key, false when it is not set.
+func (o demoTemplateOptions) bool(key string) (bool, error) {
+	value, ok := o[key]
+	if !ok {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("option %s: %q is not a boolean", key, value)
+	}
+	return b, nil
+}
+
+// demoCatalog is the layout of a catalog file.
+type demoCatalog struct {
+	Demos []Demo `yaml:"demos"`
+}
+
+// DefaultDemoCatalogPath returns the local catalog extending the built-in
+// one when no other is given: demos.yaml in the infocusp directory of the
+// user's configuration directory.
+func DefaultDemoCatalogPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "infocusp", "demos.yaml"), nil
+}
+
+// LoadDemoCatalog returns the built-in demos extended with those of the
+// catalog file at path, in order. A demo of the file replaces the built-in
+// demo of the same name. An empty path loads the built-in demos only.
+func LoadDemoCatalog(path string) ([]Demo, error) {
+	demos, err := parseDemoCatalog(builtinDemos)
+	if err != nil {
+		return nil, fmt.Errorf("built-in demo catalog: %w", err)
+	}
+	if path == "" {
+		return demos, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	local, err := parseDemoCatalog(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	index := map[string]int{}
+	for i, demo := range demos {
+		index[demo.Name] = i
+	}
+	for _, demo := range local {
+		if i, ok := index[demo.Name]; ok {
+			demos[i] = demo
+			continue
+		}
+		index[demo.Name] = len(demos)
+		demos = append(demos, demo)
+	}
+	return demos, nil
+}
+
+// parseDemoCatalog parses and validates a catalog file.
+func parseDemoCatalog(content []byte) ([]Demo, error) {
+	var catalog demoCatalog
+	if err := yaml.Unmarshal(content, &catalog); err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, demo := range catalog.Demos {
+		if err := demo.validate(); err != nil {
+			return nil, err
+		}
+		if seen[demo.Name] {
+			return nil, fmt.Errorf("demo %q is listed twice", demo.Name)
+		}
+		seen[demo.Name] = true
+	}
+	return catalog.Demos, nil
+}
+
+// FindDemo returns the demo called name.
+func FindDemo(demos []Demo, name string) (Demo, error) {
+	names := make([]string, 0, len(demos))
+	for _, demo := range demos {
+		if demo.Name == name {
+			return demo, nil
+		}
+		names = append(names, demo.Name)
+	}
+	return Demo{}, fmt.Errorf("unknown demo %q (choose one of %s)", name, strings.Join(names, ", "))
+}
+
+// DemoOptions configures CreateDemo.
+type DemoOptions struct {
+	Options
+
+	// Demo is the catalog entry to create. An empty Name means the name of
+	// the demo.
+	Demo Demo
+
+	// Clone clones the repository at url into dir, for demos with a Repo.
+	Clone func(url, dir string) error
+
+	// SkipSetup only prints the setup steps of the demo instead of running
+	// them.
+	SkipSetup bool
+}
+
+// CreateDemo creates a demo of the catalog in a new project directory below
+// opts.Dir, from its template or by cloning its repository, runs its setup
+// steps there and prints how to run it.
+func CreateDemo(ctx context.Context, opts DemoOptions) error {
+	demo := opts.Demo
+	if err := demo.validate(); err != nil {
+		return err
+	}
+	if opts.Name == "" {
+		opts.Name = demo.Name
+	}
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+
+	if demo.Repo != "" {
+		if opts.Clone == nil {
+			return fmt.Errorf("demo %q is cloned from a repository, but no Clone function was given", demo.Name)
+		}
+		if _, err := os.Stat(projectDir); err == nil {
+			return fmt.Errorf("creating project directory: %s already exists", projectDir)
+		}
+		opts.printf("Cloning %s...\n", demo.Repo)
+		if err := opts.Clone(demo.Repo, projectDir); err != nil {
+			return fmt.Errorf("cloning %s: %w", demo.Repo, err)
+		}
+	} else if err := demoTemplates[demo.Template].Create(ctx, opts.Options, demo.Options); err != nil {
+		return err
+	}
+
+	// Run the setup steps through the shell, so they can use its syntax
+	if opts.SkipSetup && len(demo.Setup) > 0 {
+		opts.printf("\nSkipped the setup steps; run them in %s:\n", projectDir)
+		for _, step := range demo.Setup {
+			opts.printf("  %s\n", step)
+		}
+	} else {
+		for _, step := range demo.Setup {
+			opts.printf("Running `%s`...\n", step)
+			if err := opts.run(ctx, projectDir, []string{"sh", "-c", step}); err != nil {
+				return fmt.Errorf("setting up demo %q: %w", demo.Name, err)
+			}
+		}
+	}
+
+	opts.printf("\nDemo '%s' is ready in %s.\n", demo.Name, projectDir)
+	if demo.Instructions != "" {
+		opts.printf("\n%s", demo.Instructions)
+		if !strings.HasSuffix(demo.Instructions, "\n") {
+			opts.printf("\n")
+		}
+	}
+	return nil
+}
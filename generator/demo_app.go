@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DemoAppOptions configures CreateDemoApp.
+type DemoAppOptions struct {
+	Options
+
+	// Framework is one of DemoFrameworks. Empty means "Streamlit".
+	Framework string
+}
+
+// DemoFrameworks lists the frameworks a demo app can be built with.
+var DemoFrameworks = []string{"Streamlit", "Gradio"}
+
+// demoPorts maps each of DemoFrameworks to the port its server listens on.
+var demoPorts = map[string]int{
+	"Streamlit": 8501,
+	"Gradio":    7860,
+}
+
+// demoTemplateData is the data the demo app templates are rendered with.
+type demoTemplateData struct {
+	Name      string
+	Framework string
+
+	// Package is the import name of the package holding the settings and
+	// the data and model hooks.
+	Package string
+
+	Port  int
+	Image string
+}
+
+// CreateDemoApp creates a Streamlit or Gradio demo in a new project directory
+// below opts.Dir: a data explorer page and a model playground page, backed by
+// replaceable data and model hooks, with settings read from the environment,
+// sample data, tests of the hooks, requirements and a Dockerfile.
+func CreateDemoApp(ctx context.Context, opts DemoAppOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+	if err := opts.preflight(ctx, "create-demo-app"); err != nil {
+		return err
+	}
+
+	files, err := DemoAppFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	answers := demoAnswers{Framework: opts.Framework}
+	if err := writeManifest(projectDir, "create-demo-app", opts.Name, answers, files); err != nil {
+		return err
+	}
+
+	if err := opts.writeCI(projectDir, pythonCIJob(projectDir, ".", opts.Name, "pytest")); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "python"); err != nil {
+		return err
+	}
+
+	// Success message for the project
+	opts.printf("Demo app '%s' created successfully!\n", opts.Name)
+	return nil
+}
+
+// DemoAppFiles renders the files of a demo app without writing them to disk.
+func DemoAppFiles(opts DemoAppOptions) (Files, error) {
+	framework := opts.Framework
+	if framework == "" {
+		framework = "Streamlit"
+	}
+	port, ok := demoPorts[framework]
+	if !ok {
+		return nil, fmt.Errorf("unsupported framework %q (choose one of %s)", opts.Framework, strings.Join(DemoFrameworks, ", "))
+	}
+
+	data := demoTemplateData{
+		Name:      opts.Name,
+		Framework: framework,
+		Package:   strings.ToLower(pythonIdentifier(opts.Name)),
+		Port:      port,
+		Image:     kubernetesName(opts.Name),
+	}
+	files, err := renderTemplates("demo/common", data)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := renderTemplates("demo/"+strings.ToLower(framework), data)
+	if err != nil {
+		return nil, err
+	}
+	for path, content := range pages {
+		files[path] = content
+	}
+	return files, nil
+}
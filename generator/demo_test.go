@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadDemoCatalog(t *testing.T) {
+	builtin, err := LoadDemoCatalog("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	local := filepath.Join(t.TempDir(), "demos.yaml")
+	content := `demos:
+  - name: sales-dashboard
+    description: Our dashboard
+    template: create-demo-app
+    options:
+      framework: Gradio
+  - name: internal-tools
+    description: Cloned
+    repo: https://example.com/internal-tools.git
+`
+	if err := os.WriteFile(local, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	demos, err := LoadDemoCatalog(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(demos) != len(builtin)+1 || demos[len(demos)-1].Name != "internal-tools" {
+		t.Fatalf("LoadDemoCatalog returned %d demos, want the %d built-in ones and internal-tools last", len(demos), len(builtin))
+	}
+	demo, err := FindDemo(demos, "sales-dashboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if demo.Description != "Our dashboard" || demo.Options["framework"] != "Gradio" {
+		t.Errorf("sales-dashboard = %+v, want the local entry", demo)
+	}
+}
+
+func TestLoadDemoCatalogRejectsInvalidDemos(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "unknown option",
+			content: "demos:\n  - name: typo\n    template: create-demo-app\n    options:\n      framwork: Gradio\n",
+			want:    `demo "typo" sets unknown option "framwork" of create-demo-app`,
+		},
+		{
+			name:    "unknown template",
+			content: "demos:\n  - name: rust\n    template: create-rust-app\n",
+			want:    `demo "rust" uses unknown template "create-rust-app"`,
+		},
+		{
+			name:    "two sources",
+			content: "demos:\n  - name: both\n    template: create-demo-app\n    repo: https://example.com/both.git\n",
+			want:    `demo "both" sets both a template and a repo`,
+		},
+		{
+			name:    "duplicate",
+			content: "demos:\n  - name: twice\n    repo: https://example.com/a.git\n  - name: twice\n    repo: https://example.com/b.git\n",
+			want:    `demo "twice" is listed twice`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			local := filepath.Join(t.TempDir(), "demos.yaml")
+			if err := os.WriteFile(local, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadDemoCatalog(local); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadDemoCatalog = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
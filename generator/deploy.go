@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DeployTargets lists the deployment configurations the Python skeletons
+// can be generated with.
+var DeployTargets = []string{"kubernetes", "helm", "cloud-run", "app-engine"}
+
+// googleCloudTargets are the DeployTargets running on Google Cloud, which
+// need GoogleCloudOptions.
+var googleCloudTargets = map[string]bool{
+	"cloud-run":  true,
+	"app-engine": true,
+}
+
+// IsGoogleCloudTarget reports whether the deployment target runs on Google
+// Cloud and so needs GoogleCloudOptions.
+func IsGoogleCloudTarget(target string) bool {
+	return googleCloudTargets[target]
+}
+
+// GoogleCloudOptions locates the Google Cloud project a skeleton deploys to.
+// When ProjectID is set, images are named after its Artifact Registry
+// repository, for every deployment target, and the dependencies are listed in
+// requirements.in and pinned into requirements.txt.
+type GoogleCloudOptions struct {
+	ProjectID string `json:"projectId,omitempty"`
+
+	// Region hosts the service and the registry. Empty means "us-central1".
+	Region string `json:"region,omitempty"`
+
+	// Repository is the Artifact Registry Docker repository. Empty means
+	// the project name.
+	Repository string `json:"repository,omitempty"`
+}
+
+// healthPath is the endpoint the skeletons serve for liveness and readiness
+// checks when a deployment target is chosen.
+const healthPath = "/health"
+
+// deployTemplateData is the data the deployment templates are rendered with.
+type deployTemplateData struct {
+	// Name is the project name as a Kubernetes or Cloud Run resource name.
+	Name string
+
+	// Image is the container image, without tag.
+	Image string
+
+	// Port is the port the container serves HTTP on.
+	Port int
+
+	HealthPath string
+
+	// ProjectID, Region and Repository locate the Google Cloud project and
+	// its Artifact Registry repository, empty when there is none.
+	ProjectID  string
+	Region     string
+	Repository string
+
+	// Entrypoint starts the app on App Engine, listening on $PORT.
+	Entrypoint string
+}
+
+// kubernetesNamePattern matches the runs of characters not allowed in
+// Kubernetes resource names.
+var kubernetesNamePattern = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// kubernetesName turns name into a valid Kubernetes resource name: lower
+// case alphanumerics and dashes, at most 63 characters.
+func kubernetesName(name string) string {
+	name = kubernetesNamePattern.ReplaceAllString(strings.ToLower(name), "-")
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "app"
+	}
+	return name
+}
+
+// deployFiles renders the deployment configuration for target of the
+// project called name, whose container serves HTTP on port and which App
+// Engine starts with entrypoint. An empty target renders nothing.
+func deployFiles(target, name string, port int, entrypoint string, gcp GoogleCloudOptions) (Files, error) {
+	if target == "" {
+		return Files{}, nil
+	}
+	if err := validateDeploy(target, gcp); err != nil {
+		return nil, err
+	}
+
+	data := deployTemplateData{
+		Name:       kubernetesName(name),
+		Port:       port,
+		HealthPath: healthPath,
+		ProjectID:  gcp.ProjectID,
+		Entrypoint: entrypoint,
+	}
+	data.Image = data.Name
+	if gcp.ProjectID != "" {
+		data.Region = gcp.Region
+		if data.Region == "" {
+			data.Region = "us-central1"
+		}
+		data.Repository = gcp.Repository
+		if data.Repository == "" {
+			data.Repository = data.Name
+		}
+		data.Image = fmt.Sprintf("%s-docker.pkg.dev/%s/%s/%s", data.Region, gcp.ProjectID, data.Repository, data.Name)
+	}
+	return renderTemplates("deploy/"+target, data)
+}
+
+// validateDeploy checks that target is one of DeployTargets, or empty, and
+// that Google Cloud targets have a project.
+func validateDeploy(target string, gcp GoogleCloudOptions) error {
+	if target == "" {
+		return nil
+	}
+	for _, t := range DeployTargets {
+		if t != target {
+			continue
+		}
+		if IsGoogleCloudTarget(target) && gcp.ProjectID == "" {
+			return fmt.Errorf("deploying to %s needs a Google Cloud project ID", target)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported deployment target %q (choose one of %s)", target, strings.Join(DeployTargets, ", "))
+}
+
+// addRequirements lists the requirements of a Python skeleton in
+// requirements.txt or, for a Google Cloud project, in requirements.in, from
+// which compileRequirements writes requirements.txt.
+func addRequirements(files Files, requirements string, gcp GoogleCloudOptions) {
+	if gcp.ProjectID == "" {
+		files["requirements.txt"] = requirements
+		return
+	}
+	files["requirements.in"] = requirements
+}
+
+// compileRequirements pins the dependencies listed in requirements.in, with
+// their hashes, into requirements.txt with pip-compile, when the project has
+// a requirements.in. Without pip-compile, or when it fails, requirements.txt
+// lists the dependencies unpinned and a warning explains how to pin them
+// later.
+//
+// requirements.txt is left out of the templates and so of the manifest:
+// `infocusp upgrade` merges requirements.in and leaves the pinned file alone.
+func (o Options) compileRequirements(ctx context.Context, projectDir string) error {
+	requirements, err := os.ReadFile(filepath.Join(projectDir, "requirements.in"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	unpinned := func(reason error) error {
+		o.printf("Warning: %v; run `pip-compile --generate-hashes requirements.in` to pin the dependencies in requirements.txt.\n", reason)
+		return os.WriteFile(filepath.Join(projectDir, "requirements.txt"), requirements, 0644)
+	}
+
+	tool, _ := LookupTool("pip-compile")
+	if check := CheckTool(ctx, tool); check.Err != nil {
+		return unpinned(check.Err)
+	}
+
+	o.printf("Pinning dependencies with pip-compile...\n")
+	err = o.run(ctx, projectDir, []string{"pip-compile", "--quiet", "--strip-extras", "--generate-hashes", "--output-file", "requirements.txt", "requirements.in"})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return unpinned(err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// deployRenderers render a Python skeleton for a deployment target.
+var deployRenderers = map[string]func(target string) (Files, error){
+	"fastapi": func(target string) (Files, error) {
+		return FastAPIFiles(FastAPIOptions{Options: Options{Name: "shop"}, TestingFramework: "pytest", Deploy: target})
+	},
+	"flask": func(target string) (Files, error) {
+		return FlaskFiles(FlaskOptions{Options: Options{Name: "shop"}, TestingFramework: "pytest", Deploy: target})
+	},
+}
+
+// probe is the part of a container probe the tests check.
+type probe struct {
+	HTTPGet struct {
+		Path string `yaml:"path"`
+	} `yaml:"httpGet"`
+}
+
+// k8sManifest is the part of a Kubernetes manifest the tests check.
+type k8sManifest struct {
+	Kind string `yaml:"kind"`
+	Spec struct {
+		Template struct {
+			Spec struct {
+				Containers []struct {
+					LivenessProbe  probe `yaml:"livenessProbe"`
+					ReadinessProbe probe `yaml:"readinessProbe"`
+				} `yaml:"containers"`
+			} `yaml:"spec"`
+		} `yaml:"template"`
+	} `yaml:"spec"`
+}
+
+func TestKubernetesManifests(t *testing.T) {
+	for stack, render := range deployRenderers {
+		t.Run(stack, func(t *testing.T) {
+			files, err := render("kubernetes")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			kinds := map[string]bool{}
+			for _, path := range files.Paths() {
+				if !strings.HasPrefix(path, "k8s/") || !strings.HasSuffix(path, ".yaml") {
+					continue
+				}
+				var m k8sManifest
+				if err := yaml.Unmarshal([]byte(files[path]), &m); err != nil {
+					t.Fatalf("%s is not valid YAML: %v", path, err)
+				}
+				kinds[m.Kind] = true
+
+				if m.Kind != "Deployment" {
+					continue
+				}
+				containers := m.Spec.Template.Spec.Containers
+				if len(containers) != 1 {
+					t.Fatalf("%s has %d containers, want 1", path, len(containers))
+				}
+				if got := containers[0].LivenessProbe.HTTPGet.Path; got != healthPath {
+					t.Errorf("liveness probe path = %q, want %q", got, healthPath)
+				}
+				if got := containers[0].ReadinessProbe.HTTPGet.Path; got != healthPath {
+					t.Errorf("readiness probe path = %q, want %q", got, healthPath)
+				}
+			}
+
+			for _, kind := range []string{"Deployment", "Service", "ConfigMap", "HorizontalPodAutoscaler"} {
+				if !kinds[kind] {
+					t.Errorf("no %s manifest rendered", kind)
+				}
+			}
+			if main := files["app/main.py"]; !strings.Contains(main, `"`+healthPath+`"`) && !strings.Contains(main, `'`+healthPath+`'`) {
+				t.Errorf("app/main.py does not serve %s", healthPath)
+			}
+		})
+	}
+}
+
+func TestHelmChart(t *testing.T) {
+	for stack, render := range deployRenderers {
+		t.Run(stack, func(t *testing.T) {
+			files, err := render("helm")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var values []string
+			for _, path := range files.Paths() {
+				if strings.HasPrefix(path, "charts/shop/values") {
+					values = append(values, path)
+				}
+			}
+			sort.Strings(values)
+			want := []string{"charts/shop/values-dev.yaml", "charts/shop/values-prod.yaml", "charts/shop/values-staging.yaml", "charts/shop/values.yaml"}
+			if strings.Join(values, " ") != strings.Join(want, " ") {
+				t.Fatalf("values files = %v, want %v", values, want)
+			}
+
+			for _, path := range append(values, "charts/shop/Chart.yaml") {
+				var decoded map[string]any
+				if err := yaml.Unmarshal([]byte(files[path]), &decoded); err != nil {
+					t.Errorf("%s is not valid YAML: %v", path, err)
+				}
+				if len(decoded) == 0 {
+					t.Errorf("%s is empty", path)
+				}
+			}
+
+			var defaults struct {
+				HealthPath string `yaml:"healthPath"`
+			}
+			if err := yaml.Unmarshal([]byte(files["charts/shop/values.yaml"]), &defaults); err != nil {
+				t.Fatal(err)
+			}
+			if defaults.HealthPath != healthPath {
+				t.Errorf("values.yaml healthPath = %q, want %q", defaults.HealthPath, healthPath)
+			}
+			deployment := files["charts/shop/templates/deployment.yaml"]
+			for _, name := range []string{"livenessProbe", "readinessProbe"} {
+				if !strings.Contains(deployment, name+":\n            httpGet:\n              path: {{ .Values.healthPath }}") {
+					t.Errorf("%s of the chart's deployment does not check .Values.healthPath", name)
+				}
+			}
+		})
+	}
+}
+
+// fakePipCompile puts on PATH a pip-compile that records its arguments in
+// the file args of the project and writes pinned as requirements.txt.
+func fakePipCompile(t *testing.T, pinned string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake pip-compile is a shell script")
+	}
+	bin := t.TempDir()
+	script := fmt.Sprintf(`#!/bin/sh
+if [ "$1" = --version ]; then
+	echo "pip-compile, version 7.4.1"
+	exit 0
+fi
+printf '%%s\n' "$*" > args
+printf %q > requirements.txt
+`, pinned)
+	if err := os.WriteFile(filepath.Join(bin, "pip-compile"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+}
+
+func TestCompileRequirements(t *testing.T) {
+	const pinned = "flask==3.0.3 --hash=sha256:34e815dfaa43340d1d15a5c3a02b8476004037eb4840b34910c6e21679d288f3\n"
+	gcp := GoogleCloudOptions{ProjectID: "acme-prod"}
+
+	tests := []struct {
+		name       string
+		pipCompile bool
+		deploy     string
+	}{
+		{name: "pinned", pipCompile: true, deploy: "cloud-run"},
+		{name: "kubernetes in a Google Cloud project", pipCompile: true, deploy: "kubernetes"},
+		{name: "without pip-compile", deploy: "app-engine"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.pipCompile {
+				fakePipCompile(t, pinned)
+			} else {
+				t.Setenv("PATH", t.TempDir())
+			}
+			opts := FlaskOptions{
+				Options:          Options{Dir: t.TempDir(), Name: "shop", skipPreflight: true},
+				TestingFramework: "pytest",
+				Deploy:           tt.deploy,
+				GoogleCloud:      gcp,
+			}
+			if err := CreateFlaskSkeleton(context.Background(), opts); err != nil {
+				t.Fatal(err)
+			}
+			dir := filepath.Join(opts.Dir, "shop")
+
+			requirements, err := os.ReadFile(filepath.Join(dir, "requirements.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			manifest, err := ReadManifest(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.pipCompile {
+				if string(requirements) != pinned {
+					t.Errorf("requirements.txt = %q, want the pinned requirements", requirements)
+				}
+				args, err := os.ReadFile(filepath.Join(dir, "args"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(string(args), "--generate-hashes") {
+					t.Errorf("pip-compile %s, want --generate-hashes", strings.TrimSpace(string(args)))
+				}
+			} else if string(requirements) != manifest.Files["requirements.in"] {
+				t.Errorf("requirements.txt = %q, want the unpinned requirements.in", requirements)
+			}
+
+			if _, ok := manifest.Files["requirements.txt"]; ok {
+				t.Error("manifest records requirements.txt, which pip-compile writes")
+			}
+			report, err := Upgrade(context.Background(), UpgradeOptions{ProjectDir: dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed := len(report.Updated) + len(report.Created) + len(report.Removed) + len(report.Conflicts) + len(report.Skipped); changed > 0 {
+				t.Errorf("upgrading an unchanged project reported %+v", *report)
+			}
+			after, err := os.ReadFile(filepath.Join(dir, "requirements.txt"))
+			if err != nil || string(after) != string(requirements) {
+				t.Errorf("upgrade changed requirements.txt to %q (%v)", after, err)
+			}
+		})
+	}
+}
+
+func TestRequirementsWithoutGoogleCloud(t *testing.T) {
+	files, err := FlaskFiles(FlaskOptions{Options: Options{Name: "shop"}, TestingFramework: "pytest", Deploy: "kubernetes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := files["requirements.in"]; ok {
+		t.Error("requirements.in rendered without a Google Cloud project")
+	}
+	if _, ok := files["requirements.txt"]; !ok {
+		t.Error("requirements.txt not rendered")
+	}
+}
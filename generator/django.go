@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DjangoOptions configures CreateDjangoSkeleton.
+type DjangoOptions struct {
+	Options
+
+	// TestingFramework is "unittest", "pytest" or "None".
+	TestingFramework string
+
+	// Docker adds a Dockerfile serving the project with gunicorn.
+	Docker bool
+
+	// DependencyManager is "pip", listing the dependencies in
+	// requirements.txt, or "pip-tools", listing them in requirements.in and
+	// pinning them, with hashes, into requirements.txt with pip-compile when
+	// it is installed. Empty means "pip".
+	DependencyManager string
+}
+
+// PythonDependencyManagers lists the ways a Python skeleton can manage its
+// dependencies.
+var PythonDependencyManagers = []string{"pip", "pip-tools"}
+
+// djangoTemplateData is the data the Django templates are rendered with.
+type djangoTemplateData struct {
+	Name string
+
+	// Package is the project's Python package, holding settings and URLs.
+	Package string
+
+	TestingFramework string
+	Docker           bool
+	PipTools         bool
+}
+
+// CreateDjangoSkeleton creates a Django project with an items app exposing
+// an Item model through the admin and a Django REST framework API, settings
+// split per environment and optional tests, in a new project directory below
+// opts.Dir. The project is rendered from templates rather than by
+// `django-admin startproject`, so Django need not be installed.
+func CreateDjangoSkeleton(ctx context.Context, opts DjangoOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+	if err := opts.preflight(ctx, "create-django-skeleton"); err != nil {
+		return err
+	}
+
+	files, err := DjangoFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	answers := djangoAnswers{TestingFramework: opts.TestingFramework, Docker: opts.Docker, DependencyManager: opts.DependencyManager}
+	if err := writeManifest(projectDir, "create-django-skeleton", opts.Name, answers, files); err != nil {
+		return err
+	}
+	if err := opts.compileRequirements(ctx, projectDir); err != nil {
+		return err
+	}
+
+	job := pythonCIJob(projectDir, ".", opts.Name, opts.TestingFramework)
+	job.Test = djangoTestCommand(opts.TestingFramework)
+	if err := opts.writeCI(projectDir, job); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "python"); err != nil {
+		return err
+	}
+
+	// Success message for the project
+	opts.printf("Django skeleton project '%s' created successfully!\n", opts.Name)
+	return nil
+}
+
+// djangoReservedPackages are the packages a Django skeleton imports, its
+// own items app included. manage.py puts the project root first on the
+// import path, so a project package of the same name would shadow them.
+var djangoReservedPackages = map[string]bool{
+	"django":         true,
+	"gunicorn":       true,
+	"items":          true,
+	"os":             true,
+	"pathlib":        true,
+	"pytest":         true,
+	"pytest_django":  true,
+	"rest_framework": true,
+	"sys":            true,
+}
+
+// ValidateDjangoProjectName checks that the package of a Django project
+// called name does not shadow a package the skeleton imports.
+func ValidateDjangoProjectName(name string) error {
+	if pkg := pythonIdentifier(name); djangoReservedPackages[pkg] {
+		return fmt.Errorf("project name %q would make a package %s, shadowing the %s package the project imports; choose another name", name, pkg, pkg)
+	}
+	return nil
+}
+
+// DjangoFiles renders the files of a Django skeleton project without writing
+// them to disk.
+func DjangoFiles(opts DjangoOptions) (Files, error) {
+	if err := ValidateDjangoProjectName(opts.Name); err != nil {
+		return nil, err
+	}
+	if err := validateDependencyManager(opts.DependencyManager); err != nil {
+		return nil, err
+	}
+
+	return renderTemplates("django", djangoTemplateData{
+		Name:             opts.Name,
+		Package:          pythonIdentifier(opts.Name),
+		TestingFramework: optionValue(opts.TestingFramework),
+		Docker:           opts.Docker,
+		PipTools:         opts.DependencyManager == "pip-tools",
+	})
+}
+
+// djangoTestCommand returns the command CI runs the tests of a Django
+// project with. manage.py switches to the test settings by itself.
+func djangoTestCommand(testingFramework string) string {
+	switch testingFramework {
+	case "pytest":
+		return "pytest"
+	case "unittest":
+		return "python manage.py test"
+	default:
+		// Without tests, at least run Django's system checks
+		return "python manage.py check"
+	}
+}
+
+// validateDependencyManager checks that manager is one of
+// PythonDependencyManagers, or empty.
+func validateDependencyManager(manager string) error {
+	if manager == "" {
+		return nil
+	}
+	for _, m := range PythonDependencyManagers {
+		if m == manager {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported dependency manager %q (choose one of %s)", manager, strings.Join(PythonDependencyManagers, ", "))
+}
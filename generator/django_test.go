@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDjangoFilesRejectsShadowingNames(t *testing.T) {
+	for _, name := range []string{"items", "django", "rest_framework", "rest-framework", "pytest"} {
+		if _, err := DjangoFiles(DjangoOptions{Options: Options{Name: name}, TestingFramework: "pytest"}); err == nil || !strings.Contains(err.Error(), "shadowing") {
+			t.Errorf("DjangoFiles(%q) = %v, want an error about shadowing a package", name, err)
+		}
+	}
+	for _, name := range []string{"shop", "my-items", "django_shop"} {
+		if _, err := DjangoFiles(DjangoOptions{Options: Options{Name: name}, TestingFramework: "pytest"}); err != nil {
+			t.Errorf("DjangoFiles(%q) = %v", name, err)
+		}
+	}
+}
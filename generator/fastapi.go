@@ -0,0 +1,295 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FastAPIOptions configures CreateFastAPISkeleton.
+type FastAPIOptions struct {
+	Options
+
+	// TestingFramework is "unittest", "pytest" or "None".
+	TestingFramework string
+
+	// CORSOrigins lists the browser origins allowed to call the API. When
+	// set, main.py installs FastAPI's CORSMiddleware for them.
+	CORSOrigins []string
+
+	// OpenAPI, when set, replaces the sample Item models and routes with
+	// Pydantic schemas for the document's components, a router per tag with
+	// stub handlers for its operations, and contract tests for every path.
+	OpenAPI *OpenAPISpec
+
+	// ModelServing replaces the sample Item models and routes with an
+	// inference API: a /predict endpoint taking batches of instances, /health
+	// and /metadata endpoints, and a model loaded at startup, a stub until
+	// MODEL_PATH points to a trained one. It cannot be combined with OpenAPI.
+	ModelServing bool
+
+	// Deploy is one of DeployTargets, or empty. It adds the deployment
+	// configuration and a health endpoint for its probes.
+	Deploy string
+
+	// GoogleCloud locates the project to deploy to. It is required by
+	// Google Cloud targets, and names the images of the others. With a
+	// project, the dependencies are listed in requirements.in and pinned,
+	// with hashes, into requirements.txt with pip-compile when it is
+	// installed.
+	GoogleCloud GoogleCloudOptions
+}
+
+// CreateFastAPISkeleton creates the directory structure and files necessary
+// for a basic FastAPI application, including models, schemas, routes, and
+// optional tests, in a new project directory below opts.Dir.
+func CreateFastAPISkeleton(ctx context.Context, opts FastAPIOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+	if err := opts.preflight(ctx, "create-fastapi-skeleton"); err != nil {
+		return err
+	}
+
+	files, err := FastAPIFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	answers := fastAPIAnswers{TestingFramework: opts.TestingFramework, CORSOrigins: opts.CORSOrigins, OpenAPI: opts.OpenAPI, ModelServing: opts.ModelServing, Deploy: opts.Deploy, GoogleCloud: opts.GoogleCloud}
+	if err := writeManifest(projectDir, "create-fastapi-skeleton", opts.Name, answers, files); err != nil {
+		return err
+	}
+	if err := opts.compileRequirements(ctx, projectDir); err != nil {
+		return err
+	}
+	if err := opts.writeCI(projectDir, pythonCIJob(projectDir, ".", opts.Name, opts.TestingFramework)); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "python"); err != nil {
+		return err
+	}
+
+	// Success message for tests
+	if opts.TestingFramework != "None" && opts.TestingFramework != "" {
+		opts.printf("Testing framework '%s' set up successfully in '%s/tests'.\n", opts.TestingFramework, opts.Name)
+	}
+
+	// Success message for the project
+	opts.printf("FastAPI skeleton project '%s' created successfully!\n", opts.Name)
+	return nil
+}
+
+// FastAPIFiles renders the files of a FastAPI skeleton project without
+// writing them to disk.
+func FastAPIFiles(opts FastAPIOptions) (Files, error) {
+	if opts.ModelServing && opts.OpenAPI != nil {
+		return nil, fmt.Errorf("a model-serving project cannot be generated from an OpenAPI document")
+	}
+
+	files := Files{}
+
+	// Create __init__.py
+	files["app/__init__.py"] = ""
+
+	// Create main.py with router imports, allowing cross-origin calls from a frontend when asked to
+	mainPyContent := `from fastapi import FastAPI
+from .routes import router
+
+app = FastAPI()
+`
+	if len(opts.CORSOrigins) > 0 {
+		mainPyContent = `from fastapi import FastAPI
+from fastapi.middleware.cors import CORSMiddleware
+from .routes import router
+
+app = FastAPI()
+
+app.add_middleware(
+    CORSMiddleware,
+    allow_origins=` + pythonStringList(opts.CORSOrigins) + `,
+    allow_credentials=True,
+    allow_methods=["*"],
+    allow_headers=["*"],
+)
+`
+	}
+	files["app/main.py"] = mainPyContent + `
+app.include_router(router)
+
+@app.get("/")
+def read_root():
+    return {"message": "Hello, World!"}
+`
+
+	// Create models.py with a dummy model
+	files["app/models.py"] = `from pydantic import BaseModel
+
+class Item(BaseModel):
+    name: str
+    description: str = None
+    price: float
+    tax: float = None
+`
+
+	// Create schemas.py with Pydantic schema
+	files["app/schemas.py"] = `from pydantic import BaseModel
+
+class ItemSchema(BaseModel):
+    name: str
+    description: str = None
+    price: float
+    tax: float = None
+`
+
+	// Create routes.py with example endpoints
+	files["app/routes.py"] = `from fastapi import APIRouter
+from .schemas import ItemSchema
+from .models import Item
+
+router = APIRouter()
+
+@router.post("/items/")
+def create_item(item: ItemSchema):
+    return {"message": "Item created", "item": item}
+
+@router.get("/items/{item_id}")
+def get_item(item_id: int):
+    return {"message": "Get item", "item_id": item_id}
+`
+
+	// Create requirements.txt
+	requirementsContent := `fastapi
+fastapi[standard]
+uvicorn[standard]
+`
+
+	// Create Dockerfile
+	files["Dockerfile"] = `FROM tiangolo/uvicorn-gunicorn-fastapi:python3.8
+
+COPY ./app /app
+`
+
+	// Create .gitignore
+	files[".gitignore"] = `.venv/
+__pycache__/
+*.pyc
+`
+
+	// Set up testing framework based on user choice
+	if opts.TestingFramework == "pytest" {
+		files["tests/__init__.py"] = ""
+
+		// Write basic pytest test file
+		files["tests/test_main.py"] = `from fastapi.testclient import TestClient
+from app.main import app
+
+client = TestClient(app)
+
+def test_read_root():
+    response = client.get("/")
+    assert response.status_code == 200
+    assert response.json() == {"message": "Hello, World!"}
+`
+
+		// Add pytest to requirements.txt
+		requirementsContent += "\npytest\n"
+	} else if opts.TestingFramework == "unittest" {
+		files["tests/__init__.py"] = ""
+
+		// Write basic unittest test file
+		files["tests/test_main.py"] = `import unittest
+from fastapi.testclient import TestClient
+from app.main import app
+
+client = TestClient(app)
+
+class TestMain(unittest.TestCase):
+    def test_read_root(self):
+        response = client.get("/")
+        self.assertEqual(response.status_code, 200)
+        self.assertEqual(response.json(), {"message": "Hello, World!"})
+
+if __name__ == '__main__':
+    unittest.main()
+`
+
+		// unittest is part of the Python standard library, so no need to add it to requirements.txt
+	}
+
+	// Load trained models, such as scikit-learn estimators, with joblib
+	if opts.ModelServing {
+		requirementsContent += "joblib\n"
+	}
+
+	addRequirements(files, requirementsContent, opts.GoogleCloud)
+
+	// Generate the app from the OpenAPI document instead of the sample Item
+	if opts.OpenAPI != nil {
+		delete(files, "app/routes.py")
+		specFiles, err := fastAPIOpenAPIFiles(opts)
+		if err != nil {
+			return nil, err
+		}
+		for path, content := range specFiles {
+			files[path] = content
+		}
+		if !strings.Contains(files["app/main.py"], "def read_root") {
+			delete(files, "tests/test_main.py")
+		}
+	}
+
+	// Serve a model instead of the sample Item
+	if opts.ModelServing {
+		data := fastAPIServingData{TestingFramework: opts.TestingFramework}
+		if len(opts.CORSOrigins) > 0 {
+			data.CORSOrigins = pythonStringList(opts.CORSOrigins)
+		}
+		servingFiles, err := renderTemplates("fastapi/serving", data)
+		if err != nil {
+			return nil, err
+		}
+		for path, content := range servingFiles {
+			files[path] = content
+		}
+	}
+
+	// Serve a health endpoint for the probes of the deployment, on port 80 of the base image
+	if opts.Deploy != "" && !opts.ModelServing {
+		files["app/main.py"] += `
+@app.get("` + healthPath + `")
+def health():
+    return {"status": "ok"}
+`
+	}
+	deploy, err := deployFiles(opts.Deploy, opts.Name, 80, "uvicorn app.main:app --host 0.0.0.0 --port $PORT", opts.GoogleCloud)
+	if err != nil {
+		return nil, err
+	}
+	for path, content := range deploy {
+		files[path] = content
+	}
+	return files, nil
+}
+
+// fastAPIServingData is the data the templates of a model-serving FastAPI
+// project are rendered with.
+type fastAPIServingData struct {
+	CORSOrigins      string // Python list literal, empty when CORS is not set up
+	TestingFramework string
+}
+
+// pythonStringList formats values as a Python list literal of strings.
+func pythonStringList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,622 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// fastAPIOpenAPIData is the data the project-level templates of a FastAPI
+// project generated from an OpenAPI document are rendered with.
+type fastAPIOpenAPIData struct {
+	Title       string
+	Version     string
+	CORSOrigins string // Python list literal, empty when CORS is not set up
+	Root        bool   // whether the spec leaves GET / to the skeleton
+	Routers     []string
+	Imports     string // import block of schemas.py
+	Models      []pythonModel
+}
+
+// pythonModel is a component schema as a Pydantic model, or as a type alias
+// when the schema is not an object.
+type pythonModel struct {
+	Name        string
+	Description string
+	Alias       string // type aliased when the schema is not an object
+	Fields      []pythonModelField
+	ByAlias     bool // whether some field is renamed from its JSON name
+}
+
+// pythonModelField is one annotated attribute of a Pydantic model.
+type pythonModelField struct {
+	Name    string
+	Type    string
+	Default string // right-hand side of the annotation, empty when required
+}
+
+// fastAPIRouterData is the data a router module and its contract tests are
+// rendered with, one per OpenAPI tag.
+type fastAPIRouterData struct {
+	Module           string
+	Class            string // test case class name
+	Tags             string // Python list literal of the router's tag
+	Imports          string // import block of the router module
+	TestImports      string // import block of the test module
+	TestingFramework string
+	Routes           []fastAPIRoute
+}
+
+// fastAPIRoute is one operation as a FastAPI path operation function and the
+// request its contract test sends.
+type fastAPIRoute struct {
+	Method        string // lower case, e.g. get
+	Path          string
+	Function      string
+	Summary       string
+	Status        int
+	ResponseModel string // response_model argument, empty for no body
+	Params        string // function parameters
+	Return        string // Python literal the stub returns
+	TestRequest   string // arguments of client.request
+	Validate      string // type the response body is validated against
+}
+
+// pythonKeywords are reserved words that cannot name Python variables.
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true,
+	"await": true, "break": true, "class": true, "continue": true, "def": true, "del": true, "elif": true,
+	"else": true, "except": true, "finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true, "not": true, "or": true,
+	"pass": true, "raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// pathParamPattern matches the {name} placeholders of an OpenAPI path.
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// fastAPIOpenAPIFiles renders the app/ modules and contract tests of a
+// FastAPI project generated from opts.OpenAPI.
+func fastAPIOpenAPIFiles(opts FastAPIOptions) (Files, error) {
+	spec := opts.OpenAPI
+
+	data := fastAPIOpenAPIData{
+		Title:   spec.Title,
+		Version: spec.Version,
+		Root:    true,
+	}
+	if len(opts.CORSOrigins) > 0 {
+		data.CORSOrigins = pythonStringList(opts.CORSOrigins)
+	}
+
+	var schemaCode []string
+	for _, name := range sortedSchemaNames(spec) {
+		model := newPythonModel(spec, name)
+		data.Models = append(data.Models, model)
+		schemaCode = append(schemaCode, model.Alias)
+		if model.ByAlias {
+			schemaCode = append(schemaCode, "ConfigDict()")
+		}
+		for _, field := range model.Fields {
+			schemaCode = append(schemaCode, field.Type, field.Default)
+		}
+	}
+	code := strings.Join(schemaCode, "\n")
+	data.Imports = pythonImports(code, "from pydantic import "+calledNames(code, "BaseModel", "ConfigDict", "Field"))
+
+	// Group operations into one router per tag, in order of first appearance
+	routers := map[string]*fastAPIRouterData{}
+	var tags []string
+	for _, op := range spec.Operations {
+		if op.Method == "GET" && op.Path == "/" {
+			data.Root = false
+		}
+		if routers[op.Tag] == nil {
+			routers[op.Tag] = &fastAPIRouterData{
+				Module:           uniqueName(pythonIdentifier(op.Tag), data.Routers),
+				Class:            pythonClassName(op.Tag),
+				Tags:             pythonStringList([]string{op.Tag}),
+				TestingFramework: opts.TestingFramework,
+			}
+			tags = append(tags, op.Tag)
+			data.Routers = append(data.Routers, routers[op.Tag].Module)
+		}
+		router := routers[op.Tag]
+		var names []string
+		for _, route := range router.Routes {
+			names = append(names, route.Function)
+		}
+		router.Routes = append(router.Routes, newFastAPIRoute(spec, op, names))
+	}
+
+	files, err := renderTemplates("fastapi/openapi/project", data)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		router := routers[tag]
+
+		var lines, validated []string
+		for _, route := range router.Routes {
+			lines = append(lines, route.ResponseModel, route.Params)
+			if route.Validate != "" {
+				validated = append(validated, route.Validate)
+			}
+		}
+		code := strings.Join(lines, "\n")
+		router.Imports = pythonImports(code, "from fastapi import "+calledNames(code, "APIRouter", "Header", "Query"), localSchemasImport(code, "from .. import schemas"))
+
+		testCode := strings.Join(validated, "\n")
+		testImports := ""
+		if len(validated) > 0 {
+			testImports = "from pydantic import TypeAdapter"
+		}
+		router.TestImports = pythonImports(testCode, "from fastapi.testclient import TestClient\n"+testImports, "from app.main import app\n"+localSchemasImport(testCode, "from app import schemas"))
+
+		routerFiles, err := renderTemplates("fastapi/openapi/router", router)
+		if err != nil {
+			return nil, err
+		}
+		for path, content := range routerFiles {
+			files[path] = content
+		}
+	}
+	return files, nil
+}
+
+// localSchemasImport returns line when code refers to the schemas module.
+func localSchemasImport(code, line string) string {
+	if strings.Contains(code, "schemas.") {
+		return line
+	}
+	return ""
+}
+
+// sortedSchemaNames returns the component schema names in document order,
+// moving each schema after the schemas it references so Pydantic can
+// resolve them when the module is imported.
+func sortedSchemaNames(spec *OpenAPISpec) []string {
+	var names []string
+	visited := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] || spec.Schemas[name] == nil {
+			return
+		}
+		visited[name] = true
+		for _, ref := range schemaRefs(spec.Schemas[name]) {
+			visit(ref)
+		}
+		names = append(names, name)
+	}
+	for _, name := range spec.SchemaNames {
+		visit(name)
+	}
+	return names
+}
+
+// schemaRefs lists the component names a schema references.
+func schemaRefs(s *OpenAPISchema) []string {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		return []string{s.RefName()}
+	}
+	refs := schemaRefs(s.Items)
+	for _, name := range s.PropertyNames {
+		refs = append(refs, schemaRefs(s.Properties[name])...)
+	}
+	for _, part := range s.AllOf {
+		refs = append(refs, schemaRefs(part)...)
+	}
+	return refs
+}
+
+// newPythonModel converts the component schema name to a Pydantic model.
+func newPythonModel(spec *OpenAPISpec, name string) pythonModel {
+	schema := spec.Schemas[name]
+	model := pythonModel{
+		Name:        pythonClassName(name),
+		Description: pythonDocstring(schema.Description),
+	}
+
+	object := spec.Flatten(schema)
+	if len(object.Properties) == 0 {
+		model.Alias = pythonType(object, "")
+		return model
+	}
+
+	for _, property := range object.PropertyNames {
+		field := pythonModelField{
+			Name: uniqueName(pythonIdentifier(property), fieldNames(model.Fields)),
+			Type: pythonType(object.Properties[property], ""),
+		}
+
+		required := object.IsRequired(property)
+		if !required {
+			field.Type = optionalType(field.Type)
+		}
+		switch {
+		case field.Name != property && required:
+			field.Default = "Field(alias=" + strconv.Quote(property) + ")"
+		case field.Name != property:
+			field.Default = "Field(None, alias=" + strconv.Quote(property) + ")"
+		case !required:
+			field.Default = "None"
+		}
+		model.ByAlias = model.ByAlias || field.Name != property
+		model.Fields = append(model.Fields, field)
+	}
+	return model
+}
+
+// fieldNames returns the names of fields.
+func fieldNames(fields []pythonModelField) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return names
+}
+
+// newFastAPIRoute converts an operation to a stub path operation function.
+// taken lists the function names already used in the router.
+func newFastAPIRoute(spec *OpenAPISpec, op OpenAPIOperation, taken []string) fastAPIRoute {
+	function := op.OperationID
+	if function == "" {
+		function = strings.ToLower(op.Method) + "_" + op.Path
+	}
+
+	route := fastAPIRoute{
+		Method:   strings.ToLower(op.Method),
+		Function: uniqueName(pythonIdentifier(function), taken),
+		Summary:  pythonDocstring(op.Summary),
+		Status:   op.Status,
+		Return:   "None",
+	}
+
+	// Placeholders are renamed to the function's parameter names
+	paramNames := map[string]string{}
+	var required, optional []string
+	var testParams, testHeaders []string
+	testURL := op.Path
+	var used []string
+	for _, param := range op.Parameters {
+		name := uniqueName(pythonIdentifier(param.Name), used)
+		used = append(used, name)
+		typ := pythonType(param.Schema, "schemas.")
+		sample := pythonSample(spec, param.Schema, 0)
+
+		switch param.In {
+		case "path":
+			paramNames[param.Name] = name
+			required = append(required, name+": "+typ)
+			testURL = strings.ReplaceAll(testURL, "{"+param.Name+"}", strings.Trim(sample, `"`))
+			continue
+		case "query":
+			if param.Required {
+				testParams = append(testParams, strconv.Quote(param.Name)+": "+sample)
+			}
+		case "header":
+			if param.Required {
+				testHeaders = append(testHeaders, strconv.Quote(param.Name)+": "+strconv.Quote(strings.Trim(sample, `"`)))
+			}
+		}
+
+		kind := "Query"
+		if param.In == "header" {
+			kind = "Header"
+		}
+		// Headers, renamed parameters and lists need FastAPI told where they come from
+		var args []string
+		if !param.Required {
+			args = append(args, "None")
+		}
+		aliased := name != param.Name || param.In == "header"
+		if aliased {
+			args = append(args, "alias="+strconv.Quote(param.Name))
+		}
+		explicit := aliased || strings.HasPrefix(typ, "List[")
+		switch {
+		case param.Required && !explicit:
+			required = append(required, name+": "+typ)
+		case param.Required:
+			optional = append(optional, name+": "+typ+" = "+kind+"("+strings.Join(args, ", ")+")")
+		case !explicit:
+			optional = append(optional, name+": "+optionalType(typ)+" = None")
+		default:
+			optional = append(optional, name+": "+optionalType(typ)+" = "+kind+"("+strings.Join(args, ", ")+")")
+		}
+	}
+	route.Path = pathParamPattern.ReplaceAllStringFunc(op.Path, func(placeholder string) string {
+		if name, ok := paramNames[placeholder[1:len(placeholder)-1]]; ok {
+			return "{" + name + "}"
+		}
+		return placeholder
+	})
+
+	testRequest := []string{strconv.Quote(op.Method), strconv.Quote(testURL)}
+	if op.RequestBody != nil {
+		typ := pythonType(op.RequestBody, "schemas.")
+		body := uniqueName("body", used)
+		if op.RequestBodyRequired {
+			required = append(required, body+": "+typ)
+		} else {
+			optional = append(optional, body+": "+optionalType(typ)+" = None")
+		}
+		testRequest = append(testRequest, "json="+pythonSample(spec, op.RequestBody, 0))
+	}
+	if len(testParams) > 0 {
+		testRequest = append(testRequest, "params={"+strings.Join(testParams, ", ")+"}")
+	}
+	if len(testHeaders) > 0 {
+		testRequest = append(testRequest, "headers={"+strings.Join(testHeaders, ", ")+"}")
+	}
+	route.TestRequest = strings.Join(testRequest, ", ")
+
+	// Parameters with defaults must follow the ones without
+	route.Params = strings.Join(append(required, optional...), ", ")
+
+	if op.Response != nil && op.Method != "HEAD" && op.Status != 204 {
+		route.ResponseModel = pythonType(op.Response, "schemas.")
+		route.Return = pythonSample(spec, op.Response, 0)
+		route.Validate = route.ResponseModel
+	}
+	return route
+}
+
+// pythonType returns the Python annotation for a schema. References to
+// component schemas are prefixed with qualifier, e.g. "schemas.".
+func pythonType(s *OpenAPISchema, qualifier string) string {
+	typ := pythonBaseType(s, qualifier)
+	if s != nil && s.Nullable {
+		return optionalType(typ)
+	}
+	return typ
+}
+
+// optionalType makes the annotation typ accept None.
+func optionalType(typ string) string {
+	if typ == "Any" || strings.HasPrefix(typ, "Optional[") {
+		return typ
+	}
+	return "Optional[" + typ + "]"
+}
+
+// pythonBaseType returns the Python annotation for a schema, ignoring
+// whether it is nullable.
+func pythonBaseType(s *OpenAPISchema, qualifier string) string {
+	switch {
+	case s == nil:
+		return "Any"
+	case s.Ref != "":
+		return qualifier + pythonClassName(s.RefName())
+	case len(s.AllOf) == 1:
+		return pythonType(s.AllOf[0], qualifier)
+	case len(s.AllOf) > 1:
+		// Only component schemas get a model, so inline compositions stay untyped
+		return "Dict[str, Any]"
+	case len(s.Enum) > 0:
+		values := make([]string, len(s.Enum))
+		for i, value := range s.Enum {
+			values[i] = pythonLiteral(value)
+		}
+		return "Literal[" + strings.Join(values, ", ") + "]"
+	}
+
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date":
+			return "datetime.date"
+		case "date-time":
+			return "datetime.datetime"
+		}
+		return "str"
+	case "integer":
+		return "int"
+	case "number":
+		return "float"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "List[" + pythonType(s.Items, qualifier) + "]"
+	case "object":
+		return "Dict[str, Any]"
+	}
+	return "Any"
+}
+
+// pythonSample returns a Python literal, also valid JSON input, that
+// validates against the schema. Examples in the document are preferred.
+func pythonSample(spec *OpenAPISpec, s *OpenAPISchema, depth int) string {
+	switch {
+	case s == nil:
+		return "None"
+	case s.Example != nil:
+		return pythonLiteral(s.Example)
+	case s.Ref != "":
+		if depth > 4 || spec.Schemas[s.RefName()] == nil {
+			return "None"
+		}
+		return pythonSample(spec, spec.Schemas[s.RefName()], depth+1)
+	case len(s.AllOf) > 0:
+		return pythonSample(spec, spec.Flatten(s), depth)
+	case len(s.Enum) > 0:
+		return pythonLiteral(s.Enum[0])
+	case len(s.Properties) > 0:
+		var items []string
+		for _, name := range s.PropertyNames {
+			// Optional properties are left out of nested samples to bound recursion
+			if depth > 1 && !s.IsRequired(name) {
+				continue
+			}
+			items = append(items, strconv.Quote(name)+": "+pythonSample(spec, s.Properties[name], depth+1))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date":
+			return `"2024-01-01"`
+		case "date-time":
+			return `"2024-01-01T00:00:00Z"`
+		case "email":
+			return `"user@example.com"`
+		case "uuid":
+			return `"00000000-0000-0000-0000-000000000000"`
+		}
+		return `"string"`
+	case "integer":
+		return "1"
+	case "number":
+		return "1.5"
+	case "boolean":
+		return "True"
+	case "array":
+		return "[" + pythonSample(spec, s.Items, depth+1) + "]"
+	case "object":
+		return "{}"
+	}
+	return "None"
+}
+
+// pythonLiteral formats a value decoded from YAML as a Python literal.
+func pythonLiteral(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "None"
+	case bool:
+		if v {
+			return "True"
+		}
+		return "False"
+	case string:
+		return strconv.Quote(v)
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = pythonLiteral(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, key := range keys {
+			items[i] = strconv.Quote(key) + ": " + pythonLiteral(v[key])
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return strconv.Quote(fmt.Sprint(value))
+}
+
+// pythonImports returns the import block of a module containing code: the
+// datetime and typing imports it needs, followed by each non-empty group of
+// imports, separated by blank lines.
+func pythonImports(code string, groups ...string) string {
+	var stdlib []string
+	if regexp.MustCompile(`\bdatetime\.`).MatchString(code) {
+		stdlib = append(stdlib, "import datetime")
+	}
+	var typing []string
+	for _, name := range []string{"Any", "Dict", "List", "Literal", "Optional"} {
+		if regexp.MustCompile(`\b` + name + `\b`).MatchString(code) {
+			typing = append(typing, name)
+		}
+	}
+	if len(typing) > 0 {
+		stdlib = append(stdlib, "from typing import "+strings.Join(typing, ", "))
+	}
+
+	blocks := []string{strings.Join(stdlib, "\n")}
+	blocks = append(blocks, groups...)
+	var nonEmpty []string
+	for _, block := range blocks {
+		if block = strings.TrimSpace(block); block != "" {
+			nonEmpty = append(nonEmpty, block)
+		}
+	}
+	return strings.Join(nonEmpty, "\n\n")
+}
+
+// calledNames returns name followed by those of names that code calls.
+func calledNames(code, name string, names ...string) string {
+	used := []string{name}
+	for _, name := range names {
+		if strings.Contains(code, name+"(") {
+			used = append(used, name)
+		}
+	}
+	return strings.Join(used, ", ")
+}
+
+// pythonDocstring returns the first line of text, made safe to place
+// between triple quotes.
+func pythonDocstring(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return strings.NewReplacer(`\`, `\\`, `"`, `'`).Replace(strings.TrimSpace(line))
+}
+
+// pythonIdentifier converts a name such as "petId" or "X-Request-ID" to a
+// snake_case Python identifier.
+func pythonIdentifier(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '_'
+	}, name)
+	identifier := strings.Join(splitWords(cleaned), "_")
+	if identifier == "" || unicode.IsDigit(rune(identifier[0])) {
+		identifier = "_" + identifier
+	}
+	if pythonKeywords[identifier] {
+		identifier += "_"
+	}
+	return identifier
+}
+
+// pythonClassName converts a schema name such as "pet_status" to PascalCase.
+func pythonClassName(name string) string {
+	var class string
+	for _, word := range strings.Split(pythonIdentifier(name), "_") {
+		if word != "" {
+			class += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	if class == "" || unicode.IsDigit(rune(class[0])) {
+		class = "Model" + class
+	}
+	return class
+}
+
+// uniqueName returns name, suffixed with a number if it is already taken.
+func uniqueName(name string, taken []string) string {
+	candidate := name
+	for i := 2; ; i++ {
+		clash := false
+		for _, t := range taken {
+			if t == candidate {
+				clash = true
+				break
+			}
+		}
+		if !clash {
+			return candidate
+		}
+		candidate = name + "_" + strconv.Itoa(i)
+	}
+}
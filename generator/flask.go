@@ -0,0 +1,220 @@
+package generator
+
+import (
+	"context"
+	"strings"
+)
+
+// FlaskOptions configures CreateFlaskSkeleton.
+type FlaskOptions struct {
+	Options
+
+	// TestingFramework is "unittest", "pytest" or "None".
+	TestingFramework string
+
+	// Deploy is one of DeployTargets, or empty. It adds the deployment
+	// configuration and a health endpoint for its probes.
+	Deploy string
+
+	// GoogleCloud locates the project to deploy to. It is required by
+	// Google Cloud targets, and names the images of the others. With a
+	// project, the dependencies are listed in requirements.in and pinned,
+	// with hashes, into requirements.txt with pip-compile when it is
+	// installed.
+	GoogleCloud GoogleCloudOptions
+}
+
+// CreateFlaskSkeleton creates a Flask project with models, routes, schemas,
+// and optional tests in a new project directory below opts.Dir.
+func CreateFlaskSkeleton(ctx context.Context, opts FlaskOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+	if err := opts.preflight(ctx, "create-flask-skeleton"); err != nil {
+		return err
+	}
+
+	files, err := FlaskFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	if err := writeManifest(projectDir, "create-flask-skeleton", opts.Name, flaskAnswers{TestingFramework: opts.TestingFramework, Deploy: opts.Deploy, GoogleCloud: opts.GoogleCloud}, files); err != nil {
+		return err
+	}
+	if err := opts.compileRequirements(ctx, projectDir); err != nil {
+		return err
+	}
+	if err := opts.writeCI(projectDir, pythonCIJob(projectDir, ".", opts.Name, opts.TestingFramework)); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "python"); err != nil {
+		return err
+	}
+
+	// Success message for tests
+	if opts.TestingFramework != "None" && opts.TestingFramework != "" {
+		opts.printf("Testing framework '%s' set up successfully in '%s/tests'.\n", opts.TestingFramework, opts.Name)
+	}
+
+	// Success message for the project
+	opts.printf("Flask skeleton project '%s' created successfully!\n", opts.Name)
+	return nil
+}
+
+// FlaskFiles renders the files of a Flask skeleton project without writing
+// them to disk.
+func FlaskFiles(opts FlaskOptions) (Files, error) {
+	files := Files{}
+
+	// Create __init__.py
+	files["app/__init__.py"] = ""
+
+	// Create main.py with a basic Flask app
+	files["app/main.py"] = `from flask import Flask, jsonify
+
+app = Flask(__name__)
+
+@app.route('/')
+def index():
+    return jsonify({"message": "Hello, World!"})
+
+if __name__ == "__main__":
+    app.run(debug=True)
+`
+
+	// Create models.py with a dummy model (this can later integrate with an ORM like SQLAlchemy)
+	files["app/models.py"] = `class Item:
+    def __init__(self, name, description, price, tax=None):
+        self.name = name
+        self.description = description
+        self.price = price
+        self.tax = tax
+`
+
+	// Create schemas.py with simple schema logic
+	files["app/schemas.py"] = `class ItemSchema:
+    def __init__(self, name, description, price, tax=None):
+        self.name = name
+        self.description = description
+        self.price = price
+        self.tax = tax
+`
+
+	// Create routes.py with example endpoints
+	files["app/routes.py"] = `from flask import Blueprint, jsonify, request
+from .schemas import ItemSchema
+
+bp = Blueprint('routes', __name__)
+
+@bp.route('/items', methods=['POST'])
+def create_item():
+    data = request.json
+    item = ItemSchema(**data)
+    return jsonify({"message": "Item created", "item": data})
+
+@bp.route('/items/<int:item_id>', methods=['GET'])
+def get_item(item_id):
+    return jsonify({"message": "Get item", "item_id": item_id})
+`
+
+	// Create requirements.txt
+	requirementsContent := `flask
+`
+
+	// App Engine serves the app with gunicorn rather than the development server
+	if opts.Deploy == "app-engine" {
+		requirementsContent += "gunicorn\n"
+	}
+
+	// Create Dockerfile
+	files["Dockerfile"] = `FROM python:3.12-slim
+
+WORKDIR /code
+
+COPY requirements.txt .
+RUN pip install --no-cache-dir -r requirements.txt
+
+COPY ./app ./app
+
+EXPOSE 5000
+CMD ["flask", "--app", "app.main", "run", "--host", "0.0.0.0", "--port", "5000"]
+`
+
+	// Create .gitignore
+	files[".gitignore"] = `.venv/
+__pycache__/
+*.pyc
+`
+
+	// Set up testing framework based on user choice
+	if opts.TestingFramework == "pytest" {
+		files["tests/__init__.py"] = ""
+
+		// Write basic pytest test file
+		files["tests/test_main.py"] = `from app.main import app
+import pytest
+
+@pytest.fixture
+def client():
+    app.config['TESTING'] = True
+    with app.test_client() as client:
+        yield client
+
+def test_index(client):
+    rv = client.get('/')
+    assert rv.status_code == 200
+    assert rv.get_json() == {"message": "Hello, World!"}
+`
+
+		// Add pytest to requirements.txt
+		requirementsContent += "\npytest\n"
+	} else if opts.TestingFramework == "unittest" {
+		files["tests/__init__.py"] = ""
+
+		// Write basic unittest test file
+		files["tests/test_main.py"] = `import unittest
+from app.main import app
+
+class TestMain(unittest.TestCase):
+    def setUp(self):
+        app.config['TESTING'] = True
+        self.client = app.test_client()
+
+    def test_index(self):
+        rv = self.client.get('/')
+        self.assertEqual(rv.status_code, 200)
+        self.assertEqual(rv.get_json(), {"message": "Hello, World!"})
+
+if __name__ == '__main__':
+    unittest.main()
+`
+
+		// unittest is part of the Python standard library, so no need to add it to requirements.txt
+	}
+
+	addRequirements(files, requirementsContent, opts.GoogleCloud)
+	// Serve a health endpoint for the probes of the deployment
+	if opts.Deploy != "" {
+		files["app/main.py"] = strings.Replace(files["app/main.py"], `
+if __name__ == "__main__":`, `
+@app.route('`+healthPath+`')
+def health():
+    return jsonify({"status": "ok"})
+
+if __name__ == "__main__":`, 1)
+	}
+	deploy, err := deployFiles(opts.Deploy, opts.Name, 5000, "gunicorn --bind :$PORT app.main:app", opts.GoogleCloud)
+	if err != nil {
+		return nil, err
+	}
+	for path, content := range deploy {
+		files[path] = content
+	}
+	return files, nil
+}
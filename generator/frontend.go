@@ -0,0 +1,483 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// FrontendOptions configures CreateFrontend. TypeScript, Tailwind, Linting,
+// UnitTests, E2ETesting and PackageManager apply to every framework, each
+// with the tooling idiomatic for it.
+type FrontendOptions struct {
+	Options
+
+	// Framework is one of FrontendFrameworks. Empty means "React".
+	Framework string
+
+	// TypeScript writes the app in TypeScript. Angular apps always are.
+	TypeScript bool
+
+	// Tailwind adds Tailwind CSS.
+	Tailwind bool
+
+	// Linting adds ESLint with the plugins of the framework and a lint
+	// script.
+	Linting bool
+
+	// UnitTests adds a component test, run with Vitest, or with Karma and
+	// Jasmine for Angular.
+	UnitTests bool
+
+	// E2ETesting is one of FrontendE2ETools.
+	E2ETesting string
+
+	// PackageManager installs the dependencies and is referenced by the
+	// generated scripts and README. The zero value uses the package manager
+	// detected for Dir.
+	PackageManager PackageManager
+}
+
+// FrontendFrameworks lists the frameworks a frontend can be generated with.
+var FrontendFrameworks = []string{"React", "Vue", "SvelteKit", "Angular"}
+
+// FrontendE2ETools lists the end-to-end testing tools a frontend can be
+// generated with.
+var FrontendE2ETools = []string{"Playwright", "Cypress", "None"}
+
+// frontendFramework describes how an app of one of FrontendFrameworks is laid
+// out, built and tested.
+type frontendFramework struct {
+	// Templates is the directory below templates/frontend holding the
+	// sources of the app.
+	Templates string
+
+	// Port is the port of the development server.
+	Port int
+
+	// Stylesheet is the global stylesheet, where Tailwind is imported.
+	Stylesheet string
+
+	// TypeScriptOnly frameworks ignore FrontendOptions.TypeScript.
+	TypeScriptOnly bool
+
+	// Scripts are the package scripts of the app, and TypeScriptBuild the
+	// build script replacing Scripts["build"] to type-check first.
+	Scripts         map[string]string
+	TypeScriptBuild string
+
+	// Test is the script running the unit tests.
+	Test string
+
+	Dependencies       []string
+	DevDependencies    []string
+	TypeScriptPackages []string
+	UnitTestPackages   []string
+	LintPackages       []string
+
+	// Versions overrides frontendPackageVersions for the framework.
+	Versions map[string]string
+}
+
+// frontendFrameworks maps each of FrontendFrameworks to its description.
+var frontendFrameworks = map[string]frontendFramework{
+	// React here is deliberately not CreateReactApp: create-frontend renders
+	// the same Vite app, options and Counter sample for every framework, so
+	// that `infocusp upgrade` can regenerate it. CreateReactApp runs
+	// create-react-app, whose output no manifest can reproduce, and only it
+	// offers ReactOptions' router, store, data-fetching, component library
+	// and Storybook choices.
+	"React": {
+		Templates:          "react",
+		Port:               5173,
+		Stylesheet:         "src/index.css",
+		Scripts:            map[string]string{"dev": "vite", "build": "vite build", "preview": "vite preview"},
+		TypeScriptBuild:    "tsc && vite build",
+		Test:               "vitest run",
+		Dependencies:       []string{"react", "react-dom"},
+		DevDependencies:    []string{"vite", "@vitejs/plugin-react"},
+		TypeScriptPackages: []string{"typescript", "@types/react", "@types/react-dom", "@types/node"},
+		UnitTestPackages:   []string{"vitest", "jsdom", "@testing-library/react", "@testing-library/dom"},
+		LintPackages:       []string{"eslint-plugin-react-hooks", "eslint-plugin-react-refresh"},
+	},
+	"Vue": {
+		Templates:          "vue",
+		Port:               5173,
+		Stylesheet:         "src/style.css",
+		Scripts:            map[string]string{"dev": "vite", "build": "vite build", "preview": "vite preview"},
+		TypeScriptBuild:    "vue-tsc --noEmit && vite build",
+		Test:               "vitest run",
+		Dependencies:       []string{"vue"},
+		DevDependencies:    []string{"vite", "@vitejs/plugin-vue"},
+		TypeScriptPackages: []string{"typescript", "vue-tsc", "@types/node"},
+		UnitTestPackages:   []string{"vitest", "jsdom", "@vue/test-utils"},
+		LintPackages:       []string{"eslint-plugin-vue"},
+	},
+	"SvelteKit": {
+		Templates:  "sveltekit",
+		Port:       5173,
+		Stylesheet: "src/app.css",
+		// svelte-kit sync writes the .svelte-kit/tsconfig.json the project's config extends
+		Scripts:            map[string]string{"dev": "vite dev", "build": "vite build", "preview": "vite preview", "prepare": "svelte-kit sync || echo ''"},
+		TypeScriptBuild:    "svelte-kit sync && svelte-check && vite build",
+		Test:               "vitest run",
+		DevDependencies:    []string{"svelte", "@sveltejs/kit", "@sveltejs/adapter-auto", "@sveltejs/vite-plugin-svelte", "vite"},
+		TypeScriptPackages: []string{"typescript", "svelte-check", "@types/node"},
+		UnitTestPackages:   []string{"vitest", "jsdom", "@testing-library/svelte"},
+		LintPackages:       []string{"eslint-plugin-svelte"},
+	},
+	"Angular": {
+		Templates:      "angular",
+		Port:           4200,
+		Stylesheet:     "src/styles.css",
+		TypeScriptOnly: true,
+		Scripts:        map[string]string{"dev": "ng serve", "build": "ng build", "watch": "ng build --watch --configuration development"},
+		Test:           "ng test --watch=false --browsers=ChromeHeadless",
+		Dependencies:   []string{"@angular/common", "@angular/compiler", "@angular/core", "@angular/platform-browser", "rxjs", "tslib", "zone.js"},
+		DevDependencies: []string{
+			"@angular/cli", "@angular/compiler-cli", "@angular-devkit/build-angular", "typescript",
+		},
+		UnitTestPackages: []string{"karma", "karma-chrome-launcher", "karma-coverage", "karma-jasmine", "karma-jasmine-html-reporter", "jasmine-core", "@types/jasmine"},
+		LintPackages:     []string{"angular-eslint"},
+		// Angular 19 supports TypeScript 5.5 and 5.6 only
+		Versions: map[string]string{"typescript": "~5.6.3"},
+	},
+}
+
+// frontendPackageVersions pins the version ranges of the packages the
+// frontend templates are written against.
+var frontendPackageVersions = map[string]string{
+	"react":                         "^18.3.1",
+	"react-dom":                     "^18.3.1",
+	"@vitejs/plugin-react":          "^4.3.3",
+	"@types/react":                  "^18.3.12",
+	"@types/react-dom":              "^18.3.1",
+	"eslint-plugin-react-hooks":     "^5.0.0",
+	"eslint-plugin-react-refresh":   "^0.4.14",
+	"@testing-library/react":        "^16.0.1",
+	"@testing-library/dom":          "^10.4.0",
+	"vue":                           "^3.5.12",
+	"@vitejs/plugin-vue":            "^5.1.4",
+	"vue-tsc":                       "^2.1.10",
+	"@vue/test-utils":               "^2.4.6",
+	"eslint-plugin-vue":             "^9.30.0",
+	"svelte":                        "^5.1.16",
+	"@sveltejs/kit":                 "^2.8.0",
+	"@sveltejs/adapter-auto":        "^3.3.1",
+	"@sveltejs/vite-plugin-svelte":  "^4.0.0",
+	"svelte-check":                  "^4.0.7",
+	"@testing-library/svelte":       "^5.2.4",
+	"eslint-plugin-svelte":          "^2.46.0",
+	"@angular/common":               "^19.0.0",
+	"@angular/compiler":             "^19.0.0",
+	"@angular/core":                 "^19.0.0",
+	"@angular/platform-browser":     "^19.0.0",
+	"@angular/cli":                  "^19.0.0",
+	"@angular/compiler-cli":         "^19.0.0",
+	"@angular-devkit/build-angular": "^19.0.0",
+	"rxjs":                          "~7.8.0",
+	"tslib":                         "^2.8.1",
+	"zone.js":                       "~0.15.0",
+	"angular-eslint":                "^19.0.0",
+	"karma":                         "~6.4.0",
+	"karma-chrome-launcher":         "~3.2.0",
+	"karma-coverage":                "~2.2.0",
+	"karma-jasmine":                 "~5.1.0",
+	"karma-jasmine-html-reporter":   "~2.1.0",
+	"jasmine-core":                  "~5.4.0",
+	"@types/jasmine":                "~5.1.0",
+	"vite":                          "^5.4.11",
+	"typescript":                    "^5.6.3",
+	"@types/node":                   "^20.17.6",
+	"vitest":                        "^2.1.5",
+	"jsdom":                         "^25.0.1",
+	"tailwindcss":                   "^3.4.15",
+	"postcss":                       "^8.4.49",
+	"autoprefixer":                  "^10.4.20",
+	"eslint":                        "^9.14.0",
+	"@eslint/js":                    "^9.14.0",
+	"globals":                       "^15.12.0",
+	"typescript-eslint":             "^8.13.0",
+	"@playwright/test":              "^1.48.2",
+	"cypress":                       "^13.15.2",
+	"start-server-and-test":         "^2.0.8",
+}
+
+// frontendTemplateData is the data the frontend templates are rendered with.
+type frontendTemplateData struct {
+	Name string
+
+	// PackageName is the project name as an npm package name.
+	PackageName string
+
+	Framework  string
+	TypeScript bool
+	Tailwind   bool
+	Linting    bool
+	UnitTests  bool
+	E2E        string // "Playwright", "Cypress" or ""
+
+	// Ext and JSXExt are the extensions of plain modules and of modules
+	// containing JSX.
+	Ext    string
+	JSXExt string
+
+	Port       int
+	Stylesheet string
+
+	// PackageManager and the commands spelled for it. Test, E2ETest and
+	// Lint are empty without the option.
+	PackageManager string
+	Install        string
+	Dev            string
+	Build          string
+	Test           string
+	E2ETest        string
+	Lint           string
+	Check          string
+
+	// InstallBrowsers downloads the browsers Playwright drives.
+	InstallBrowsers string
+}
+
+// CreateFrontend creates a React, Vue, SvelteKit or Angular app with a sample
+// counter component in a new project directory below opts.Dir, with the
+// chosen TypeScript, Tailwind CSS, ESLint, unit and end-to-end testing
+// options applied the way the framework expects, and installs its
+// dependencies.
+func CreateFrontend(ctx context.Context, opts FrontendOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+
+	packageManager := opts.PackageManager
+	if packageManager.Name == "" {
+		packageManager = DetectPackageManager(filepath.Dir(projectDir))
+	}
+	opts.PackageManager = packageManager
+
+	// Make sure Node.js and the package manager are usable before creating anything
+	if err := opts.preflight(ctx, "create-frontend", packageManager.Name); err != nil {
+		return err
+	}
+
+	files, err := FrontendFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	answers := frontendAnswers{
+		Framework:      opts.Framework,
+		TypeScript:     opts.TypeScript,
+		Tailwind:       opts.Tailwind,
+		Linting:        opts.Linting,
+		UnitTests:      opts.UnitTests,
+		E2ETesting:     opts.E2ETesting,
+		PackageManager: packageManager.Name,
+	}
+	if err := writeManifest(projectDir, "create-frontend", opts.Name, answers, files); err != nil {
+		return err
+	}
+
+	opts.printf("Installing dependencies with %s...\n", packageManager.Name)
+	if err := opts.run(ctx, projectDir, strings.Fields(packageManager.Install())); err != nil {
+		return err
+	}
+	if err := setPackageScripts(ctx, filepath.Join(projectDir, "package.json"), packageManager, nil); err != nil {
+		return fmt.Errorf("updating package.json: %w", err)
+	}
+
+	lint, test := "", ""
+	if opts.Linting {
+		lint = "lint"
+	}
+	if opts.UnitTests {
+		test = "test"
+	}
+	if err := opts.writeCI(projectDir, nodeCIJob(projectDir, ".", opts.Name, packageManager, lint, test, "build")); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "node"); err != nil {
+		return err
+	}
+
+	opts.printf("%s app '%s' created successfully with %s!\n", frontendFrameworkName(opts.Framework), opts.Name, packageManager.Name)
+	return nil
+}
+
+// FrontendFiles renders the files of a frontend without writing them to disk
+// or installing anything. A zero PackageManager means npm.
+func FrontendFiles(opts FrontendOptions) (Files, error) {
+	framework, data, err := frontendData(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := renderTemplates("frontend/common", data)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := renderTemplates("frontend/"+framework.Templates, data)
+	if err != nil {
+		return nil, err
+	}
+	for path, content := range sources {
+		files[path] = content
+	}
+
+	manifest, err := frontendPackageJSON(framework, data)
+	if err != nil {
+		return nil, err
+	}
+	files["package.json"] = manifest
+	return files, nil
+}
+
+// frontendFrameworkName returns the framework an empty Framework means.
+func frontendFrameworkName(framework string) string {
+	if framework == "" {
+		return "React"
+	}
+	return framework
+}
+
+// frontendData validates opts and returns the framework they select and the
+// template data for them.
+func frontendData(opts FrontendOptions) (frontendFramework, frontendTemplateData, error) {
+	name := frontendFrameworkName(opts.Framework)
+	framework, ok := frontendFrameworks[name]
+	if !ok {
+		return framework, frontendTemplateData{}, fmt.Errorf("unsupported framework %q (choose one of %s)", opts.Framework, strings.Join(FrontendFrameworks, ", "))
+	}
+
+	e2e := optionValue(opts.E2ETesting)
+	switch e2e {
+	case "", "Playwright", "Cypress":
+	default:
+		return framework, frontendTemplateData{}, fmt.Errorf("unsupported end-to-end testing tool %q (choose one of %s)", opts.E2ETesting, strings.Join(FrontendE2ETools, ", "))
+	}
+
+	pm := opts.PackageManager
+	if pm.Name == "" {
+		pm, _ = LookupPackageManager("npm")
+	}
+
+	data := frontendTemplateData{
+		Name:           opts.Name,
+		PackageName:    kubernetesName(opts.Name),
+		Framework:      name,
+		TypeScript:     opts.TypeScript || framework.TypeScriptOnly,
+		Tailwind:       opts.Tailwind,
+		Linting:        opts.Linting,
+		UnitTests:      opts.UnitTests,
+		E2E:            e2e,
+		Ext:            "js",
+		JSXExt:         "jsx",
+		Port:           framework.Port,
+		Stylesheet:     framework.Stylesheet,
+		PackageManager: pm.Name,
+		Install:        pm.Install(),
+		Dev:            pm.Run("dev"),
+		Build:          pm.Run("build"),
+	}
+	if data.TypeScript {
+		data.Ext, data.JSXExt = "ts", "tsx"
+	}
+
+	checks := []string{}
+	if data.Linting {
+		data.Lint = pm.Run("lint")
+		checks = append(checks, data.Lint)
+	}
+	if data.UnitTests {
+		data.Test = pm.Run("test")
+		checks = append(checks, data.Test)
+	}
+	checks = append(checks, data.Build)
+	data.Check = strings.Join(checks, " && ")
+	if e2e != "" {
+		data.E2ETest = pm.Run("test:e2e")
+	}
+	if e2e == "Playwright" {
+		data.InstallBrowsers = strings.Join(pm.ExecArgs("playwright", "install"), " ")
+	}
+	return framework, data, nil
+}
+
+// frontendPackageJSON returns the package.json of a frontend, with the
+// scripts and packages of the framework and of every chosen option.
+func frontendPackageJSON(framework frontendFramework, data frontendTemplateData) (string, error) {
+	scripts := map[string]string{}
+	for name, command := range framework.Scripts {
+		scripts[name] = command
+	}
+	dependencies := append([]string{}, framework.Dependencies...)
+	devDependencies := append([]string{}, framework.DevDependencies...)
+
+	if data.TypeScript {
+		if framework.TypeScriptBuild != "" {
+			scripts["build"] = framework.TypeScriptBuild
+		}
+		devDependencies = append(devDependencies, framework.TypeScriptPackages...)
+	}
+	if data.Tailwind {
+		devDependencies = append(devDependencies, "tailwindcss", "postcss", "autoprefixer")
+	}
+	if data.Linting {
+		scripts["lint"] = "eslint src"
+		devDependencies = append(devDependencies, "eslint", "@eslint/js", "globals")
+		devDependencies = append(devDependencies, framework.LintPackages...)
+		if data.TypeScript {
+			devDependencies = append(devDependencies, "typescript-eslint")
+		}
+	}
+	if data.UnitTests {
+		scripts["test"] = framework.Test
+		devDependencies = append(devDependencies, framework.UnitTestPackages...)
+	}
+	switch data.E2E {
+	case "Playwright":
+		scripts["test:e2e"] = "playwright test"
+		devDependencies = append(devDependencies, "@playwright/test")
+	case "Cypress":
+		// Cypress expects the development server to be running already
+		scripts["test:e2e"] = fmt.Sprintf("start-server-and-test dev http://localhost:%d \"cypress run\"", data.Port)
+		devDependencies = append(devDependencies, "cypress", "start-server-and-test")
+	}
+	scripts["check"] = data.Check
+
+	ranges := func(packages []string) map[string]string {
+		versions := map[string]string{}
+		for _, pkg := range packages {
+			version, ok := framework.Versions[pkg]
+			if !ok {
+				version = frontendPackageVersions[pkg]
+			}
+			versions[pkg] = version
+		}
+		return versions
+	}
+	manifest := map[string]any{
+		"name":            data.PackageName,
+		"version":         "0.1.0",
+		"private":         true,
+		"type":            "module",
+		"scripts":         scripts,
+		"devDependencies": ranges(devDependencies),
+	}
+	if len(dependencies) > 0 {
+		manifest["dependencies"] = ranges(dependencies)
+	}
+	// Encode like updateJSONFile, so installing only adds the packageManager field
+	content, err := marshalJSONFile(manifest)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FullstackOptions configures CreateFullstack.
+type FullstackOptions struct {
+	Options
+
+	// Frontend configures the React app created in frontend/. Its Options
+	// are filled in by CreateFullstack.
+	Frontend ReactOptions
+
+	// Backend configures the FastAPI service created in backend/. Its
+	// Options are filled in by CreateFullstack, and CORSOrigins defaults to
+	// the React development server.
+	Backend FastAPIOptions
+}
+
+// fullstackTemplateData is the data the repository-level templates of a
+// full-stack project are rendered with.
+type fullstackTemplateData struct {
+	Name            string
+	PackageManager  string
+	Install         string
+	Start           string
+	FrontendCommand string
+	CORSOrigins     string
+}
+
+// CreateFullstack generates a React frontend and a FastAPI backend into one
+// repository, in frontend/ and backend/. The React development server proxies
+// the API, the backend allows CORS requests from the frontend, a
+// docker-compose.yml runs both, and the sample Items page calls the /items
+// endpoints of the backend through the proxied /api prefix.
+func CreateFullstack(ctx context.Context, opts FullstackOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+
+	frontend := opts.Frontend
+	frontend.Options = opts.Options
+	frontend.Dir, frontend.Name = projectDir, "frontend"
+	if frontend.PackageManager.Name == "" {
+		frontend.PackageManager = DetectPackageManager(filepath.Dir(projectDir))
+	}
+
+	backend := opts.Backend
+	backend.Options = opts.Options
+	backend.Dir, backend.Name = projectDir, "backend"
+	if len(backend.CORSOrigins) == 0 {
+		backend.CORSOrigins = []string{"http://localhost:3000"}
+	}
+
+	// Validate the frontend options and the tools before creating anything
+	data, _, err := reactAppTemplateData(frontend)
+	if err != nil {
+		return err
+	}
+	if err := opts.preflight(ctx, "create-fullstack", frontend.PackageManager.Name); err != nil {
+		return err
+	}
+	frontend.skipPreflight, backend.skipPreflight = true, true
+
+	// The project is one repository, initialized and given a pipeline once both halves exist
+	frontend.Git, backend.Git = GitOptions{}, GitOptions{}
+	frontend.CI, backend.CI = "", ""
+
+	if err := os.MkdirAll(filepath.Dir(projectDir), 0755); err != nil {
+		return err
+	}
+	if err := os.Mkdir(projectDir, 0755); err != nil {
+		return fmt.Errorf("creating project directory: %w", err)
+	}
+
+	if err := CreateFastAPISkeleton(ctx, backend); err != nil {
+		return fmt.Errorf("creating backend: %w", err)
+	}
+	if err := CreateReactApp(ctx, frontend); err != nil {
+		return fmt.Errorf("creating frontend: %w", err)
+	}
+
+	frontendDir := filepath.Join(projectDir, "frontend")
+	packageManager := frontend.PackageManager
+
+	// create-react-app initializes its own git repository; the project is one repository
+	if err := os.RemoveAll(filepath.Join(frontendDir, ".git")); err != nil {
+		return err
+	}
+
+	// Proxy API calls from the development server to the backend
+	opts.printf("Wiring the frontend to the backend...\n")
+	if err := opts.run(ctx, frontendDir, packageManager.InstallDevArgs("http-proxy-middleware@^3")); err != nil {
+		return err
+	}
+
+	files, err := renderTemplates("fullstack/frontend", data)
+	if err != nil {
+		return err
+	}
+	if err := files.Write(frontendDir); err != nil {
+		return err
+	}
+
+	files, err = renderTemplates("fullstack/root", fullstackTemplateData{
+		Name:            opts.Name,
+		PackageManager:  packageManager.Name,
+		Install:         packageManager.Install(),
+		Start:           packageManager.Run("start"),
+		FrontendCommand: composeInstallAndRun(packageManager, "start"),
+		CORSOrigins:     strings.Join(backend.CORSOrigins, ", "),
+	})
+	if err != nil {
+		return err
+	}
+	if err := files.Write(projectDir); err != nil {
+		return err
+	}
+	backendJob := pythonCIJob(projectDir, "backend", opts.Name+"-backend", backend.TestingFramework)
+	lint, test := frontend.ciScripts()
+	frontendJob := nodeCIJob(projectDir, "frontend", opts.Name+"-frontend", packageManager, lint, test, "build")
+	backendJob.Prefix, frontendJob.Prefix = "backend-", "frontend-"
+	if err := opts.writeCI(projectDir, backendJob, frontendJob); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "python", "node"); err != nil {
+		return err
+	}
+
+	opts.printf("Full-stack project '%s' created successfully!\n", opts.Name)
+	return nil
+}
+
+// composeInstallAndRun returns the shell command that installs dependencies
+// and runs script, such as the development server, inside the node Docker
+// image, which ships with npm and yarn but needs corepack or npm to provide
+// pnpm and bun.
+func composeInstallAndRun(pm PackageManager, script string) string {
+	command := pm.Install() + " && " + pm.Run(script)
+	switch pm.Name {
+	case "pnpm":
+		return "corepack enable && " + command
+	case "bun":
+		return "npm install -g bun && " + command
+	}
+	return command
+}
@@ -0,0 +1,153 @@
+// Package generator scaffolds projects for the tech stacks supported by the
+// Infocusp Projects CLI.
+//
+// Every generator takes a context.Context and an options struct, and works
+// only with explicit paths: it never changes the process's working directory,
+// and external tools run with their exec.Cmd.Dir set to the project. This
+// makes generators safe to call from Go code and tests, including
+// concurrently for different target directories.
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+)
+
+// Options holds the settings shared by every generator.
+type Options struct {
+	// Dir is the directory the project is created in. Relative paths are
+	// resolved against the working directory at call time; empty means the
+	// working directory itself.
+	Dir string
+
+	// Name is the project name, also used as the project's directory inside Dir.
+	Name string
+
+	// Stdin is passed to interactive external tools such as `eslint --init`.
+	// Nil means they read no input.
+	Stdin io.Reader
+
+	// Stdout and Stderr receive progress messages and the output of external
+	// tools. Nil writers discard the output.
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Git optionally puts the new project under version control.
+	Git GitOptions
+
+	// CI is the service to generate a pipeline for, one of CIProviders, or
+	// empty for none.
+	CI string
+
+	// skipPreflight is set by generators that compose others and have
+	// already checked the tools.
+	skipPreflight bool
+}
+
+// ProjectDir returns the absolute path of the project directory.
+func (o Options) ProjectDir() (string, error) {
+	if o.Name == "" {
+		return "", fmt.Errorf("project name is required")
+	}
+	return filepath.Abs(filepath.Join(o.Dir, o.Name))
+}
+
+// stdout returns the writer for progress messages.
+func (o Options) stdout() io.Writer {
+	if o.Stdout == nil {
+		return io.Discard
+	}
+	return o.Stdout
+}
+
+// stderr returns the writer for error output of external tools.
+func (o Options) stderr() io.Writer {
+	if o.Stderr == nil {
+		return io.Discard
+	}
+	return o.Stderr
+}
+
+// printf writes a progress message to the configured stdout.
+func (o Options) printf(format string, args ...any) {
+	fmt.Fprintf(o.stdout(), format, args...)
+}
+
+// run executes the command line argv inside dir, streaming its output to the
+// writers configured in the options.
+func (o Options) run(ctx context.Context, dir string, argv []string) error {
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = dir
+	cmd.Stdin = o.Stdin
+	cmd.Stdout = o.stdout()
+	cmd.Stderr = o.stderr()
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return fmt.Errorf("%s not found in PATH, run `infocusp doctor` to check your toolchain: %w", argv[0], err)
+		}
+		return fmt.Errorf("running %s: %w", argv[0], err)
+	}
+	return nil
+}
+
+// Files is an in-memory set of generated files, keyed by slash-separated path
+// relative to the project root.
+type Files map[string]string
+
+// Paths returns the file paths in lexical order.
+func (f Files) Paths() []string {
+	paths := make([]string, 0, len(f))
+	for path := range f {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Write writes every file below root, creating parent directories as needed.
+func (f Files) Write(root string) error {
+	for _, path := range f.Paths() {
+		target := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, []byte(f[path]), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeProject creates the project directory, failing if it already exists,
+// and writes the files into it.
+func writeProject(dir string, files Files) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return fmt.Errorf("creating project directory: %w", err)
+	}
+	return files.Write(dir)
+}
+
+// appendLines appends lines to the text file at path, creating it if needed.
+func appendLines(path string, lines ...string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(f, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFilesRenderers(t *testing.T) {
+	tests := []struct {
+		name   string
+		render func() (Files, error)
+		want   []string
+	}{
+		{
+			name: "flask",
+			render: func() (Files, error) {
+				return FlaskFiles(FlaskOptions{Options: Options{Name: "shop"}, TestingFramework: "pytest"})
+			},
+			want: []string{"app/main.py", "app/models.py", "app/routes.py", "requirements.txt", "tests/test_main.py"},
+		},
+		{
+			name: "fastapi",
+			render: func() (Files, error) {
+				return FastAPIFiles(FastAPIOptions{Options: Options{Name: "shop"}, TestingFramework: "pytest"})
+			},
+			want: []string{"app/main.py", "app/models.py", "app/schemas.py", "app/routes.py", "tests/test_main.py"},
+		},
+		{
+			name: "django",
+			render: func() (Files, error) {
+				return DjangoFiles(DjangoOptions{Options: Options{Name: "shop"}, TestingFramework: "pytest"})
+			},
+			want: []string{"manage.py", "requirements.txt"},
+		},
+		{
+			name: "go service",
+			render: func() (Files, error) {
+				return GoServiceFiles(GoServiceOptions{Options: Options{Name: "orders"}, Router: "chi"})
+			},
+			want: []string{"go.mod", "Dockerfile"},
+		},
+		{
+			name: "python cli",
+			render: func() (Files, error) {
+				return PythonCLIFiles(PythonCLIOptions{Options: Options{Name: "tool"}, Framework: "Typer", TestingFramework: "pytest"})
+			},
+			want: []string{"pyproject.toml", "README.md"},
+		},
+		{
+			name: "ml project",
+			render: func() (Files, error) {
+				return MLProjectFiles(MLOptions{Options: Options{Name: "churn"}, Framework: "scikit-learn", Tracking: "MLflow", DVC: true})
+			},
+			want: []string{"configs/train.yaml", "src/models/train.py", "dvc.yaml", "requirements.txt"},
+		},
+		{
+			name: "demo app",
+			render: func() (Files, error) {
+				return DemoAppFiles(DemoAppOptions{Options: Options{Name: "sales demo"}, Framework: "Gradio"})
+			},
+			want: []string{"app.py", "sales_demo/data.py", "sales_demo/model.py", "pages/explore.py", "requirements.txt"},
+		},
+		{
+			name: "frontend",
+			render: func() (Files, error) {
+				return FrontendFiles(FrontendOptions{Options: Options{Name: "web"}, Framework: "Vue", TypeScript: true, E2ETesting: "None", PackageManager: PackageManager{Name: "npm"}})
+			},
+			want: []string{"package.json", "index.html", "src/App.vue"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := tt.render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, path := range tt.want {
+				if _, ok := files[path]; !ok {
+					t.Errorf("%s not rendered", path)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratorsRunConcurrently(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flaskDir, fastAPIDir := t.TempDir(), t.TempDir()
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs[0] = CreateFlaskSkeleton(context.Background(), FlaskOptions{
+			Options:          Options{Dir: flaskDir, Name: "shop", skipPreflight: true},
+			TestingFramework: "pytest",
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		errs[1] = CreateFastAPISkeleton(context.Background(), FastAPIOptions{
+			Options:          Options{Dir: fastAPIDir, Name: "shop", skipPreflight: true},
+			TestingFramework: "pytest",
+		})
+	}()
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for dir, want := range map[string]string{flaskDir: "flask", fastAPIDir: "fastapi"} {
+		requirements, err := os.ReadFile(filepath.Join(dir, "shop", "requirements.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(strings.ToLower(string(requirements)), want) {
+			t.Errorf("%s/shop/requirements.txt does not require %s:\n%s", dir, want, requirements)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "shop", ManifestFile)); err != nil {
+			t.Errorf("manifest not written: %v", err)
+		}
+	}
+
+	after, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after != wd {
+		t.Errorf("working directory changed from %s to %s", wd, after)
+	}
+}
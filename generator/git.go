@@ -0,0 +1,236 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// GitOptions configures the git repository a generator initializes in the
+// new project. The zero value leaves the project without a repository.
+type GitOptions struct {
+	// Init initializes a repository and makes an initial commit.
+	Init bool
+
+	// DefaultBranch names the initial branch. Empty means "main".
+	DefaultBranch string
+
+	// AuthorName and AuthorEmail sign the initial commit. Empty values are
+	// taken from the user's global git configuration.
+	AuthorName  string
+	AuthorEmail string
+
+	// Message is the initial commit message. Empty means "Initial commit".
+	Message string
+
+	// Remote, when set, is added as origin and the default branch is pushed
+	// to it. It may be any URL go-git supports, including a local path.
+	Remote string
+}
+
+// gitignores lists the ignore patterns for each kind of stack. A project's
+// .gitignore is completed with the entries it lacks.
+var gitignores = map[string][]string{
+	"node": {
+		"node_modules/",
+		"build/",
+		"dist/",
+		"coverage/",
+		".env.local",
+		"npm-debug.log*",
+		"yarn-debug.log*",
+		"yarn-error.log*",
+	},
+	"go": {
+		"bin/",
+		"*.test",
+		"*.out",
+		"coverage.*",
+	},
+	"python": {
+		".venv/",
+		"__pycache__/",
+		"*.pyc",
+		".pytest_cache/",
+		".coverage",
+		"*.egg-info/",
+		".env",
+	},
+}
+
+// authorPattern matches an author written as "Name <email>".
+var authorPattern = regexp.MustCompile(`^\s*(.*?)\s*<([^<>]+)>\s*$`)
+
+// ParseGitAuthor splits an author written as "Name <email>".
+func ParseGitAuthor(author string) (name, email string, err error) {
+	match := authorPattern.FindStringSubmatch(author)
+	if match == nil || match[1] == "" {
+		return "", "", fmt.Errorf("author %q must be written as \"Name <email>\"", author)
+	}
+	return match[1], match[2], nil
+}
+
+// initRepository initializes the git repository requested by o.Git in dir,
+// a project of the given kinds of stack ("node", "python"): it completes the
+// .gitignore, commits every file that is not ignored, and pushes to the
+// remote if one is set.
+func (o Options) initRepository(ctx context.Context, dir string, kinds ...string) error {
+	if !o.Git.Init && o.Git.Remote == "" {
+		return nil
+	}
+
+	branch := o.Git.DefaultBranch
+	if branch == "" {
+		branch = "main"
+	}
+	message := o.Git.Message
+	if message == "" {
+		message = "Initial commit"
+	}
+	author, err := o.Git.author()
+	if err != nil {
+		return err
+	}
+
+	var ignores []string
+	for _, kind := range kinds {
+		ignores = append(ignores, gitignores[kind]...)
+	}
+	if err := completeGitignore(filepath.Join(dir, ".gitignore"), ignores); err != nil {
+		return err
+	}
+
+	o.printf("Initializing git repository on branch '%s'...\n", branch)
+	repo, err := git.PlainInitWithOptions(dir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName(branch)},
+	})
+	if err != nil {
+		return fmt.Errorf("initializing git repository: %w", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	if err := addUnignored(worktree, dir); err != nil {
+		return fmt.Errorf("staging files: %w", err)
+	}
+	if _, err := worktree.Commit(message, &git.CommitOptions{Author: author}); err != nil {
+		return fmt.Errorf("creating initial commit: %w", err)
+	}
+
+	if o.Git.Remote == "" {
+		return nil
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{o.Git.Remote}}); err != nil {
+		return fmt.Errorf("adding remote: %w", err)
+	}
+	o.printf("Pushing '%s' to %s...\n", branch, o.Git.Remote)
+	err = repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{config.RefSpec("refs/heads/" + branch + ":refs/heads/" + branch)},
+	})
+	if err != nil {
+		return fmt.Errorf("pushing to %s: %w", o.Git.Remote, err)
+	}
+	return nil
+}
+
+// author returns the signature of the initial commit, falling back to the
+// user's global git configuration for fields that are not set.
+func (g GitOptions) author() (*object.Signature, error) {
+	name, email := g.AuthorName, g.AuthorEmail
+	if name == "" || email == "" {
+		if global, err := config.LoadConfig(config.GlobalScope); err == nil {
+			if name == "" {
+				name = global.User.Name
+			}
+			if email == "" {
+				email = global.User.Email
+			}
+		}
+	}
+	if name == "" || email == "" {
+		return nil, fmt.Errorf("commit author unknown: set it explicitly or configure git's user.name and user.email")
+	}
+	return &object.Signature{Name: name, Email: email, When: time.Now()}, nil
+}
+
+// completeGitignore appends to the .gitignore at path the patterns it does
+// not list yet, creating the file if needed.
+func completeGitignore(path string, patterns []string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	listed := map[string]bool{}
+	for _, line := range strings.Split(string(existing), "\n") {
+		listed[strings.TrimSpace(line)] = true
+	}
+	var missing []string
+	for _, pattern := range patterns {
+		// create-react-app anchors its entries, e.g. /node_modules
+		if !listed[pattern] && !listed["/"+pattern] && !listed["/"+strings.TrimSuffix(pattern, "/")] {
+			missing = append(missing, pattern)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		missing = append([]string{""}, missing...)
+	}
+	return appendLines(path, missing...)
+}
+
+// addUnignored stages every file below root that the .gitignore files do not
+// exclude. Ignored directories such as node_modules are skipped without being
+// read, which keeps this fast for freshly installed projects.
+func addUnignored(worktree *git.Worktree, root string) error {
+	var patterns []gitignore.Pattern
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		var parts []string
+		if rel != "." {
+			parts = strings.Split(filepath.ToSlash(rel), "/")
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".git" || (len(parts) > 0 && gitignore.NewMatcher(patterns).Match(parts, true)) {
+				return filepath.SkipDir
+			}
+			content, err := os.ReadFile(filepath.Join(path, ".gitignore"))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			for _, line := range strings.Split(string(content), "\n") {
+				if line = strings.TrimRight(line, "\r"); strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "#") {
+					patterns = append(patterns, gitignore.ParsePattern(line, parts))
+				}
+			}
+			return nil
+		}
+
+		if gitignore.NewMatcher(patterns).Match(parts, false) {
+			return nil
+		}
+		return worktree.AddWithOptions(&git.AddOptions{Path: filepath.ToSlash(rel), SkipStatus: true})
+	})
+}
@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func TestInitRepositoryPushesToRemote(t *testing.T) {
+	remoteDir := t.TempDir()
+	if _, err := git.PlainInit(remoteDir, true); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := FlaskFiles(FlaskOptions{Options: Options{Name: "shop"}, TestingFramework: "pytest"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "shop")
+	if err := writeProject(dir, files); err != nil {
+		t.Fatal(err)
+	}
+
+	// Leftovers of running the project, ignored through the Python entries of the .gitignore
+	ignored := Files{
+		".venv/bin/python":                     "",
+		"app/__pycache__/main.cpython-312.pyc": "",
+		".pytest_cache/v/cache/lastfailed":     "{}",
+		"shop.egg-info/PKG-INFO":               "",
+	}
+	if err := ignored.Write(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	name, email, err := ParseGitAuthor("Ada Lovelace <ada@example.com>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{Git: GitOptions{
+		Init:          true,
+		DefaultBranch: "trunk",
+		AuthorName:    name,
+		AuthorEmail:   email,
+		Message:       "Scaffold shop",
+		Remote:        remoteDir,
+	}}
+	if err := opts.initRepository(context.Background(), dir, "python"); err != nil {
+		t.Fatal(err)
+	}
+
+	remote, err := git.PlainOpen(remoteDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := remote.Reference(plumbing.NewBranchReferenceName("trunk"), true)
+	if err != nil {
+		t.Fatalf("branch trunk was not pushed: %v", err)
+	}
+	commit, err := remote.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Author.Name != "Ada Lovelace" || commit.Author.Email != "ada@example.com" {
+		t.Errorf("commit author = %s <%s>, want Ada Lovelace <ada@example.com>", commit.Author.Name, commit.Author.Email)
+	}
+	if commit.Message != "Scaffold shop" {
+		t.Errorf("commit message = %q, want %q", commit.Message, "Scaffold shop")
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range files.Paths() {
+		if _, err := tree.File(path); err != nil {
+			t.Errorf("%s was not committed", path)
+		}
+	}
+	for _, path := range ignored.Paths() {
+		if _, err := tree.File(path); err == nil {
+			t.Errorf("ignored %s was committed", path)
+		}
+	}
+
+	gitignore, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pattern := range gitignores["python"] {
+		if !strings.Contains(string(gitignore), pattern) {
+			t.Errorf(".gitignore lacks %s", pattern)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// GoServiceOptions configures CreateGoService.
+type GoServiceOptions struct {
+	Options
+
+	// Module is the Go module path, e.g. "github.com/acme/orders". Empty
+	// means the project name, lower-cased with dashes.
+	Module string
+
+	// Router is one of GoRouters. Empty means "net/http".
+	Router string
+}
+
+// GoRouters lists the HTTP routers a Go service can be generated with.
+var GoRouters = []string{"net/http", "chi", "gin"}
+
+// goRouterTemplates maps each of GoRouters to its template directory below
+// templates/go-service.
+var goRouterTemplates = map[string]string{
+	"net/http": "nethttp",
+	"chi":      "chi",
+	"gin":      "gin",
+}
+
+// goServiceVersion is the Go version the generated services require.
+const goServiceVersion = "1.22"
+
+// goServicePort is the port the generated services listen on by default.
+const goServicePort = 8080
+
+// goServiceTemplateData is the data the Go service templates are rendered
+// with.
+type goServiceTemplateData struct {
+	Name   string
+	Module string
+
+	// Binary names the built executable and Docker image.
+	Binary string
+
+	Router    string
+	GoVersion string
+	Port      int
+}
+
+// modulePathPattern matches the module paths the generator accepts: slash
+// separated elements of letters, digits, dots, dashes, underscores and tildes.
+var modulePathPattern = regexp.MustCompile(`^[A-Za-z0-9_.~-]+(/[A-Za-z0-9_.~-]+)*$`)
+
+// CreateGoService creates a Go HTTP service with the chosen router, /items
+// handlers mirroring the Python skeletons, configuration from the
+// environment, structured logging, graceful shutdown, table-driven tests, a
+// Makefile and a distroless Dockerfile, in a new project directory below
+// opts.Dir.
+func CreateGoService(ctx context.Context, opts GoServiceOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+	if err := opts.preflight(ctx, "create-go-service"); err != nil {
+		return err
+	}
+
+	files, err := GoServiceFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	answers := goServiceAnswers{Module: opts.Module, Router: opts.Router}
+	if err := writeManifest(projectDir, "create-go-service", opts.Name, answers, files); err != nil {
+		return err
+	}
+	if err := opts.tidyGoModule(ctx, projectDir); err != nil {
+		return err
+	}
+
+	if err := opts.writeCI(projectDir, goCIJob(projectDir, ".", opts.Name)); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "go"); err != nil {
+		return err
+	}
+
+	// Success message for the project
+	opts.printf("Go service '%s' created successfully!\n", opts.Name)
+	return nil
+}
+
+// GoServiceFiles renders the files of a Go service without writing them to
+// disk.
+func GoServiceFiles(opts GoServiceOptions) (Files, error) {
+	router := opts.Router
+	if router == "" {
+		router = "net/http"
+	}
+	dir, ok := goRouterTemplates[router]
+	if !ok {
+		return nil, fmt.Errorf("unsupported router %q (choose one of %s)", opts.Router, strings.Join(GoRouters, ", "))
+	}
+
+	module := opts.Module
+	if module == "" {
+		module = kubernetesName(opts.Name)
+	}
+	if !modulePathPattern.MatchString(module) {
+		return nil, fmt.Errorf("invalid module path %q", module)
+	}
+
+	data := goServiceTemplateData{
+		Name:      opts.Name,
+		Module:    module,
+		Binary:    kubernetesName(opts.Name),
+		Router:    router,
+		GoVersion: goServiceVersion,
+		Port:      goServicePort,
+	}
+	files, err := renderTemplates("go-service/project", data)
+	if err != nil {
+		return nil, err
+	}
+	handlers, err := renderTemplates("go-service/"+dir, data)
+	if err != nil {
+		return nil, err
+	}
+	for path, content := range handlers {
+		files[path] = content
+	}
+	return files, nil
+}
+
+// tidyGoModule resolves the dependencies of the module in projectDir and
+// writes its go.sum. Without Go, or when it fails, for instance offline, a
+// warning explains how to do it later.
+func (o Options) tidyGoModule(ctx context.Context, projectDir string) error {
+	tool, _ := LookupTool("go")
+	if check := CheckTool(ctx, tool); check.Err != nil {
+		o.printf("Warning: %v; run `go mod tidy` to download the dependencies.\n", check.Err)
+		return nil
+	}
+
+	o.printf("Resolving dependencies with go mod tidy...\n")
+	if err := o.run(ctx, projectDir, []string{"go", "mod", "tidy"}); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		o.printf("Warning: %v; run `go mod tidy` to download the dependencies.\n", err)
+	}
+	return nil
+}
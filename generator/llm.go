@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// LLMOptions configures CreateLLMApp.
+type LLMOptions struct {
+	Options
+
+	// Provider is one of LLMProviders. It sets the default base URL and
+	// model of the backend, both overridable from its environment. Empty
+	// means "Ollama".
+	Provider string
+
+	// Frontend adds a React chat app built with Vite in frontend/.
+	Frontend bool
+
+	// PackageManager installs the frontend's dependencies. A zero
+	// PackageManager is detected from the directory the project is created
+	// in.
+	PackageManager PackageManager
+}
+
+// LLMProviders lists the model servers an LLM app can be generated for.
+// Both are reached through the OpenAI-compatible chat completions API.
+var LLMProviders = []string{"Ollama", "OpenAI-compatible"}
+
+// llmProviderDefaults are the default base URL and model of each of
+// LLMProviders.
+var llmProviderDefaults = map[string]struct{ BaseURL, Model string }{
+	"Ollama":            {"http://localhost:11434/v1", "llama3.2"},
+	"OpenAI-compatible": {"https://api.openai.com/v1", "gpt-4o-mini"},
+}
+
+// llmFrontendOrigin is the origin of the Vite development server, allowed to
+// call the backend.
+const llmFrontendOrigin = "http://localhost:5173"
+
+// llmFrontendVersions pins the packages of the chat frontend.
+var llmFrontendVersions = map[string]string{
+	"react":                "^18.3.1",
+	"react-dom":            "^18.3.1",
+	"vite":                 "^5.4.11",
+	"@vitejs/plugin-react": "^4.3.3",
+	"typescript":           "^5.6.3",
+	"@types/react":         "^18.3.12",
+	"@types/react-dom":     "^18.3.1",
+	"@types/node":          "^20.17.6",
+	"vitest":               "^2.1.5",
+}
+
+// llmTemplateData is the data the LLM app templates are rendered with.
+type llmTemplateData struct {
+	Name     string
+	Ollama   bool
+	BaseURL  string
+	Model    string
+	Frontend bool
+
+	// CORSOrigins are the comma-separated origins the backend allows,
+	// empty without a frontend.
+	CORSOrigins string
+
+	// PackageManager and the commands spelled for it, for the frontend.
+	PackageManager  string
+	Install         string
+	Dev             string
+	Test            string
+	FrontendCommand string
+}
+
+// CreateLLMApp creates a chat application in a new project directory below
+// opts.Dir: a FastAPI backend in backend/ streaming chat completions from an
+// Ollama or OpenAI-compatible server, with prompt templates, conversation
+// memory and tests run against a stub model server, and optionally a React
+// chat frontend in frontend/, whose dependencies are installed.
+func CreateLLMApp(ctx context.Context, opts LLMOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+
+	// The frontend needs Node.js and a package manager, the backend nothing to be generated
+	var tools []string
+	if opts.Frontend {
+		if opts.PackageManager.Name == "" {
+			opts.PackageManager = DetectPackageManager(filepath.Dir(projectDir))
+		}
+		tools = append(tools, "node", opts.PackageManager.Name)
+	}
+	if err := opts.preflight(ctx, "create-llm-app", tools...); err != nil {
+		return err
+	}
+
+	files, err := LLMAppFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	answers := llmAnswers{Provider: opts.Provider, Frontend: opts.Frontend, PackageManager: opts.PackageManager.Name}
+	if err := writeManifest(projectDir, "create-llm-app", opts.Name, answers, files); err != nil {
+		return err
+	}
+
+	jobs := []ciJob{pythonCIJob(projectDir, "backend", opts.Name+"-backend", "pytest")}
+	kinds := []string{"python"}
+	if opts.Frontend {
+		frontendDir := filepath.Join(projectDir, "frontend")
+		packageManager := opts.PackageManager
+		opts.printf("Installing frontend dependencies with %s...\n", packageManager.Name)
+		if err := opts.run(ctx, frontendDir, strings.Fields(packageManager.Install())); err != nil {
+			return err
+		}
+		if err := setPackageScripts(ctx, filepath.Join(frontendDir, "package.json"), packageManager, nil); err != nil {
+			return fmt.Errorf("updating package.json: %w", err)
+		}
+
+		frontendJob := nodeCIJob(projectDir, "frontend", opts.Name+"-frontend", packageManager, "", "test", "build")
+		// Name the jobs after their half of the repository
+		jobs[0].Prefix, frontendJob.Prefix = "backend-", "frontend-"
+		jobs = append(jobs, frontendJob)
+		kinds = append(kinds, "node")
+	}
+	if err := opts.writeCI(projectDir, jobs...); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, kinds...); err != nil {
+		return err
+	}
+
+	// Success message for the project
+	opts.printf("LLM app '%s' created successfully!\n", opts.Name)
+	return nil
+}
+
+// LLMAppFiles renders the files of an LLM app without writing them to disk or
+// installing anything. A zero PackageManager means npm.
+func LLMAppFiles(opts LLMOptions) (Files, error) {
+	provider := opts.Provider
+	if provider == "" {
+		provider = "Ollama"
+	}
+	defaults, ok := llmProviderDefaults[provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider %q (choose one of %s)", opts.Provider, strings.Join(LLMProviders, ", "))
+	}
+
+	pm := opts.PackageManager
+	if pm.Name == "" {
+		pm, _ = LookupPackageManager("npm")
+	}
+	data := llmTemplateData{
+		Name:            opts.Name,
+		Ollama:          provider == "Ollama",
+		BaseURL:         defaults.BaseURL,
+		Model:           defaults.Model,
+		Frontend:        opts.Frontend,
+		PackageManager:  pm.Name,
+		Install:         pm.Install(),
+		Dev:             pm.Run("dev"),
+		Test:            pm.Run("test"),
+		FrontendCommand: composeInstallAndRun(pm, "dev"),
+	}
+	if opts.Frontend {
+		data.CORSOrigins = llmFrontendOrigin
+	}
+
+	files, err := renderTemplates("llm/root", data)
+	if err != nil {
+		return nil, err
+	}
+	dirs := []string{"backend"}
+	if opts.Frontend {
+		dirs = append(dirs, "frontend")
+	}
+	for _, dir := range dirs {
+		rendered, err := renderTemplates("llm/"+dir, data)
+		if err != nil {
+			return nil, err
+		}
+		for path, content := range rendered {
+			files[dir+"/"+path] = content
+		}
+	}
+
+	if opts.Frontend {
+		manifest, err := llmFrontendPackageJSON(kubernetesName(opts.Name) + "-frontend")
+		if err != nil {
+			return nil, err
+		}
+		files["frontend/package.json"] = manifest
+	}
+	return files, nil
+}
+
+// llmFrontendPackageJSON returns the package.json of the chat frontend.
+func llmFrontendPackageJSON(name string) (string, error) {
+	ranges := func(packages ...string) map[string]string {
+		versions := map[string]string{}
+		for _, pkg := range packages {
+			versions[pkg] = llmFrontendVersions[pkg]
+		}
+		return versions
+	}
+	manifest := map[string]any{
+		"name":    name,
+		"version": "0.1.0",
+		"private": true,
+		"type":    "module",
+		"scripts": map[string]string{
+			"dev":     "vite",
+			"build":   "tsc && vite build",
+			"preview": "vite preview",
+			"test":    "vitest run",
+		},
+		"dependencies":    ranges("react", "react-dom"),
+		"devDependencies": ranges("vite", "@vitejs/plugin-react", "typescript", "@types/react", "@types/react-dom", "@types/node", "vitest"),
+	}
+	// Encode like updateJSONFile, so installing only adds the packageManager field
+	content, err := marshalJSONFile(manifest)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
@@ -0,0 +1,135 @@
+package generator
+
+import "strings"
+
+// merge3 merges the changes made from base to theirs into ours, line by line,
+// as `git merge-file` does. Where both sides changed the same lines, both
+// versions are kept between <<<<<<<, ======= and >>>>>>> markers labelled
+// oursLabel and theirsLabel; conflicts is the number of such regions.
+func merge3(base, ours, theirs, oursLabel, theirsLabel string) (merged string, conflicts int) {
+	switch {
+	case ours == theirs || theirs == base:
+		return ours, 0
+	case ours == base:
+		return theirs, 0
+	}
+
+	b, o, t := splitLines(base), splitLines(ours), splitLines(theirs)
+	oursMatch, theirsMatch := matchLines(b, o), matchLines(b, t)
+
+	var out strings.Builder
+	write := func(lines []string) {
+		for _, line := range lines {
+			out.WriteString(line)
+		}
+	}
+	marker := func(marker string) {
+		if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+			out.WriteString("\n")
+		}
+		out.WriteString(marker + "\n")
+	}
+
+	// Lines of base kept by both sides split the files into stable lines and
+	// the chunks between them, which are merged as a whole.
+	i, x, y := 0, 0, 0
+	chunk := func(i1, x1, y1 int) {
+		baseChunk, oursChunk, theirsChunk := b[i:i1], o[x:x1], t[y:y1]
+		switch {
+		case equalLines(oursChunk, baseChunk):
+			write(theirsChunk)
+		case equalLines(theirsChunk, baseChunk), equalLines(oursChunk, theirsChunk):
+			write(oursChunk)
+		default:
+			conflicts++
+			marker("<<<<<<< " + oursLabel)
+			write(oursChunk)
+			marker("=======")
+			write(theirsChunk)
+			marker(">>>>>>> " + theirsLabel)
+		}
+	}
+	for j := range b {
+		if oursMatch[j] < 0 || theirsMatch[j] < 0 {
+			continue
+		}
+		chunk(j, oursMatch[j], theirsMatch[j])
+		out.WriteString(b[j])
+		i, x, y = j+1, oursMatch[j]+1, theirsMatch[j]+1
+	}
+	chunk(len(b), len(o), len(t))
+	return out.String(), conflicts
+}
+
+// splitLines splits text after every newline, keeping the newlines so that
+// a missing one at the end of the file survives the merge.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matchLines returns, for every line of a, the index of the line of b it is
+// paired with in a longest common subsequence of the two, or -1.
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+
+	// Generated files mostly change in a few places; pair the common prefix
+	// and suffix directly and only run the quadratic search on the middle.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		match[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		match[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lengths[i][j] is the length of the LCS of ma[i:] and mb[j:]
+	lengths := make([][]int, len(ma)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+	for i, j := 0, 0; i < len(ma) && j < len(mb); {
+		switch {
+		case ma[i] == mb[j]:
+			match[prefix+i] = prefix + j
+			i, j = i+1, j+1
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
+
+// equalLines reports whether a and b hold the same lines.
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package generator
+
+import "testing"
+
+func TestMerge3(t *testing.T) {
+	const base = "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n"
+
+	tests := []struct {
+		name, base, ours, theirs string
+		want                     string
+		conflicts                int
+	}{
+		{
+			name:   "only ours changed",
+			base:   base,
+			ours:   "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello, world\")\n}\n",
+			theirs: base,
+			want:   "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello, world\")\n}\n",
+		},
+		{
+			name:   "only theirs changed",
+			base:   base,
+			ours:   base,
+			theirs: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n",
+			want:   "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n",
+		},
+		{
+			name:   "both changed different lines",
+			base:   base,
+			ours:   "// Command hello greets.\npackage main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n",
+			theirs: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n",
+			want:   "// Command hello greets.\npackage main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n",
+		},
+		{
+			name:   "both made the same change",
+			base:   base,
+			ours:   "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n",
+			theirs: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n",
+			want:   "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n",
+		},
+		{
+			name:      "overlapping edits",
+			base:      base,
+			ours:      "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello, world\")\n}\n",
+			theirs:    "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n",
+			want:      "package main\n\nimport \"fmt\"\n\nfunc main() {\n<<<<<<< local\n\tfmt.Println(\"hello, world\")\n=======\n\tfmt.Println(\"hi\")\n>>>>>>> template\n}\n",
+			conflicts: 1,
+		},
+		{
+			name:      "two overlapping edits",
+			base:      "a\nb\nc\nd\ne\n",
+			ours:      "A\nb\nc\nd\nE\n",
+			theirs:    "1\nb\nc\nd\n5\n",
+			want:      "<<<<<<< local\nA\n=======\n1\n>>>>>>> template\nb\nc\nd\n<<<<<<< local\nE\n=======\n5\n>>>>>>> template\n",
+			conflicts: 2,
+		},
+		{
+			name:   "no trailing newline",
+			base:   "a\nb\nc",
+			ours:   "A\nb\nc",
+			theirs: "a\nb\nC",
+			want:   "A\nb\nC",
+		},
+		{
+			name:   "trailing newline added by theirs",
+			base:   "a\nb\nc",
+			ours:   "A\nb\nc",
+			theirs: "a\nb\nc\n",
+			want:   "A\nb\nc\n",
+		},
+		{
+			name:      "overlapping edits without trailing newline",
+			base:      "a\nb",
+			ours:      "a\nB",
+			theirs:    "a\nc",
+			want:      "a\n<<<<<<< local\nB\n=======\nc\n>>>>>>> template\n",
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := merge3(tt.base, tt.ours, tt.theirs, "local", "template")
+			if got != tt.want {
+				t.Errorf("merged:\n%s\nwant:\n%s", got, tt.want)
+			}
+			if conflicts != tt.conflicts {
+				t.Errorf("conflicts = %d, want %d", conflicts, tt.conflicts)
+			}
+		})
+	}
+}
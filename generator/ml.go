@@ -0,0 +1,149 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MLOptions configures CreateMLProject.
+type MLOptions struct {
+	Options
+
+	// Framework is one of MLFrameworks. Empty means "scikit-learn".
+	Framework string
+
+	// Tracking is one of MLTrackers. Empty means "None".
+	Tracking string
+
+	// DVC versions the data and models with DVC and defines the pipeline
+	// stages in dvc.yaml.
+	DVC bool
+}
+
+// MLFrameworks lists the frameworks a machine learning project can train
+// its model with.
+var MLFrameworks = []string{"PyTorch", "TensorFlow", "scikit-learn"}
+
+// MLTrackers lists the experiment trackers a machine learning project can
+// log its runs to.
+var MLTrackers = []string{"MLflow", "Weights & Biases", "None"}
+
+// mlModelFiles maps each of MLFrameworks to the file below models/ the
+// trained model is saved to.
+var mlModelFiles = map[string]string{
+	"PyTorch":      "model.pt",
+	"TensorFlow":   "model.keras",
+	"scikit-learn": "model.joblib",
+}
+
+// mlTemplateData is the data the machine learning templates are rendered
+// with.
+type mlTemplateData struct {
+	Name      string
+	Framework string
+
+	// Tracking is one of MLTrackers.
+	Tracking string
+
+	DVC       bool
+	ModelFile string
+}
+
+// CreateMLProject creates a machine learning project laid out like
+// Cookiecutter Data Science, with data, features and models modules, a
+// config-driven training entrypoint, the chosen framework and experiment
+// tracker, optional DVC pipeline stages and unit tests for the data
+// pipeline, in a new project directory below opts.Dir.
+func CreateMLProject(ctx context.Context, opts MLOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+	if err := opts.preflight(ctx, "create-ml-project"); err != nil {
+		return err
+	}
+
+	files, err := MLProjectFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	answers := mlAnswers{Framework: opts.Framework, Tracking: opts.Tracking, DVC: opts.DVC}
+	if err := writeManifest(projectDir, "create-ml-project", opts.Name, answers, files); err != nil {
+		return err
+	}
+
+	if err := opts.writeCI(projectDir, pythonCIJob(projectDir, ".", opts.Name, "pytest")); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "python"); err != nil {
+		return err
+	}
+
+	// Success message for the project
+	opts.printf("Machine learning project '%s' created successfully!\n", opts.Name)
+	return nil
+}
+
+// MLProjectFiles renders the files of a machine learning project without
+// writing them to disk.
+func MLProjectFiles(opts MLOptions) (Files, error) {
+	framework := opts.Framework
+	if framework == "" {
+		framework = "scikit-learn"
+	}
+	modelFile, ok := mlModelFiles[framework]
+	if !ok {
+		return nil, fmt.Errorf("unsupported framework %q (choose one of %s)", opts.Framework, strings.Join(MLFrameworks, ", "))
+	}
+
+	tracking := opts.Tracking
+	if tracking == "" {
+		tracking = "None"
+	}
+	if err := validateTracker(tracking); err != nil {
+		return nil, err
+	}
+
+	data := mlTemplateData{
+		Name:      opts.Name,
+		Framework: framework,
+		Tracking:  tracking,
+		DVC:       opts.DVC,
+		ModelFile: modelFile,
+	}
+	files, err := renderTemplates("ml/project", data)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.DVC {
+		return files, nil
+	}
+
+	pipeline, err := renderTemplates("ml/dvc", data)
+	if err != nil {
+		return nil, err
+	}
+	for path, content := range pipeline {
+		files[path] = content
+	}
+	// data/processed is an output of the prepare stage, which DVC refuses
+	// to track while git tracks a file inside it
+	delete(files, "data/processed/.gitkeep")
+	return files, nil
+}
+
+// validateTracker checks that tracker is one of MLTrackers.
+func validateTracker(tracker string) error {
+	for _, t := range MLTrackers {
+		if t == tracker {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported experiment tracker %q (choose one of %s)", tracker, strings.Join(MLTrackers, ", "))
+}
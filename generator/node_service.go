@@ -0,0 +1,389 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// NodeServiceOptions configures CreateNodeService.
+type NodeServiceOptions struct {
+	Options
+
+	// Framework is one of NodeFrameworks. Empty means "Express".
+	Framework string
+
+	// TypeScript writes an Express service in TypeScript. NestJS services
+	// always are.
+	TypeScript bool
+
+	// Linting adds ESLint and a lint script.
+	Linting bool
+
+	// TestingFramework is "Jest", "Mocha" or "None". NestJS services are
+	// tested with Jest only.
+	TestingFramework string
+
+	// Docker adds a multi-stage Dockerfile.
+	Docker bool
+
+	// PackageManager installs the dependencies and is referenced by the
+	// generated scripts, Dockerfile and README. The zero value uses the
+	// package manager detected for Dir.
+	PackageManager PackageManager
+}
+
+// NodeFrameworks lists the frameworks a Node.js service can be generated
+// with.
+var NodeFrameworks = []string{"Express", "NestJS"}
+
+// nodeServicePort is the port the generated services listen on by default.
+const nodeServicePort = 3000
+
+// nodePackageVersions pins the version ranges of the packages the Node.js
+// service templates are written against.
+var nodePackageVersions = map[string]string{
+	"express":                  "^4.21.1",
+	"zod":                      "^3.23.8",
+	"@nestjs/common":           "^10.4.6",
+	"@nestjs/core":             "^10.4.6",
+	"@nestjs/platform-express": "^10.4.6",
+	"@nestjs/cli":              "^10.4.5",
+	"@nestjs/testing":          "^10.4.6",
+	"class-validator":          "^0.14.1",
+	"class-transformer":        "^0.5.1",
+	"reflect-metadata":         "^0.2.2",
+	"rxjs":                     "^7.8.1",
+	"typescript":               "^5.6.3",
+	"tsx":                      "^4.19.2",
+	"ts-node":                  "^10.9.2",
+	"@types/node":              "^20.17.6",
+	"@types/express":           "^4.17.21",
+	"jest":                     "^29.7.0",
+	"ts-jest":                  "^29.2.5",
+	"@types/jest":              "^29.5.14",
+	"mocha":                    "^10.8.2",
+	"@types/mocha":             "^10.0.9",
+	"supertest":                "^7.0.0",
+	"@types/supertest":         "^6.0.2",
+	"nodemon":                  "^3.1.7",
+	"eslint":                   "^9.14.0",
+	"@eslint/js":               "^9.14.0",
+	"globals":                  "^15.12.0",
+	"typescript-eslint":        "^8.13.0",
+}
+
+// nodeServiceTemplateData is the data the Node.js service templates are
+// rendered with.
+type nodeServiceTemplateData struct {
+	Name string
+
+	// PackageName is the project name as an npm package name.
+	PackageName string
+
+	Framework        string
+	TypeScript       bool
+	Linting          bool
+	TestingFramework string // "Jest", "Mocha" or ""
+	Docker           bool
+	Port             int
+
+	// Entrypoint is the built or plain JavaScript file starting the service.
+	Entrypoint string
+
+	// PackageManager and the commands spelled for it.
+	PackageManager    string
+	Install           string
+	CleanInstall      string
+	ProductionInstall string
+	Dev               string
+	Start             string
+	Build             string // empty for plain JavaScript
+	Lint              string // empty without linting
+	Test              string // empty without tests
+	Check             string
+
+	// Lockfiles are the Dockerfile COPY sources for the lockfile, as
+	// globs so whichever one the package manager wrote is copied.
+	Lockfiles string
+
+	// Image, User and Runtime are the Docker base image, its unprivileged
+	// user and the executable running JavaScript in it.
+	Image   string
+	User    string
+	Runtime string
+}
+
+// CreateNodeService creates an Express or NestJS service with an Item CRUD
+// example, request validation with zod or class-validator, tests run with
+// Jest or Mocha, optional ESLint and Docker support, in a new project
+// directory below opts.Dir, and installs its dependencies.
+func CreateNodeService(ctx context.Context, opts NodeServiceOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+
+	packageManager := opts.PackageManager
+	if packageManager.Name == "" {
+		packageManager = DetectPackageManager(filepath.Dir(projectDir))
+	}
+	opts.PackageManager = packageManager
+
+	// Make sure Node.js and the package manager are usable before creating anything
+	if err := opts.preflight(ctx, "create-node-service", packageManager.Name); err != nil {
+		return err
+	}
+
+	files, err := NodeServiceFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	answers := nodeServiceAnswers{
+		Framework:        opts.Framework,
+		TypeScript:       opts.TypeScript,
+		Linting:          opts.Linting,
+		TestingFramework: opts.TestingFramework,
+		Docker:           opts.Docker,
+		PackageManager:   packageManager.Name,
+	}
+	if err := writeManifest(projectDir, "create-node-service", opts.Name, answers, files); err != nil {
+		return err
+	}
+
+	opts.printf("Installing dependencies with %s...\n", packageManager.Name)
+	if err := opts.run(ctx, projectDir, strings.Fields(packageManager.Install())); err != nil {
+		return err
+	}
+	if err := setPackageScripts(ctx, filepath.Join(projectDir, "package.json"), packageManager, nil); err != nil {
+		return fmt.Errorf("updating package.json: %w", err)
+	}
+
+	lint, test, build := opts.scripts()
+	if err := opts.writeCI(projectDir, nodeCIJob(projectDir, ".", opts.Name, packageManager, lint, test, build)); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "node"); err != nil {
+		return err
+	}
+
+	opts.printf("Node.js service '%s' created successfully with %s!\n", opts.Name, packageManager.Name)
+	return nil
+}
+
+// NodeServiceFiles renders the files of a Node.js service without writing
+// them to disk or installing anything. A zero PackageManager means npm.
+func NodeServiceFiles(opts NodeServiceOptions) (Files, error) {
+	data, err := nodeServiceData(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	flavor := "nestjs"
+	if data.Framework == "Express" {
+		flavor = "express-js"
+		if data.TypeScript {
+			flavor = "express-ts"
+		}
+	}
+	files, err := renderTemplates("node-service/common", data)
+	if err != nil {
+		return nil, err
+	}
+	sources, err := renderTemplates("node-service/"+flavor, data)
+	if err != nil {
+		return nil, err
+	}
+	for path, content := range sources {
+		files[path] = content
+	}
+
+	manifest, err := nodeServicePackageJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	files["package.json"] = manifest
+	return files, nil
+}
+
+// nodeServiceData validates opts and returns the template data for them.
+func nodeServiceData(opts NodeServiceOptions) (nodeServiceTemplateData, error) {
+	framework := opts.Framework
+	if framework == "" {
+		framework = "Express"
+	}
+	switch framework {
+	case "Express", "NestJS":
+	default:
+		return nodeServiceTemplateData{}, fmt.Errorf("unsupported framework %q (choose one of %s)", opts.Framework, strings.Join(NodeFrameworks, ", "))
+	}
+
+	testing := optionValue(opts.TestingFramework)
+	switch testing {
+	case "", "Jest":
+	case "Mocha":
+		if framework == "NestJS" {
+			return nodeServiceTemplateData{}, fmt.Errorf("NestJS services are tested with Jest, not Mocha")
+		}
+	default:
+		return nodeServiceTemplateData{}, fmt.Errorf("unsupported testing framework %q", opts.TestingFramework)
+	}
+
+	pm := opts.PackageManager
+	if pm.Name == "" {
+		pm, _ = LookupPackageManager("npm")
+	}
+
+	data := nodeServiceTemplateData{
+		Name:              opts.Name,
+		PackageName:       kubernetesName(opts.Name),
+		Framework:         framework,
+		TypeScript:        opts.TypeScript || framework == "NestJS",
+		Linting:           opts.Linting,
+		TestingFramework:  testing,
+		Docker:            opts.Docker,
+		Port:              nodeServicePort,
+		PackageManager:    pm.Name,
+		Install:           pm.Install(),
+		CleanInstall:      pm.CleanInstall(),
+		ProductionInstall: pm.ProductionInstall(),
+		Dev:               pm.Run("dev"),
+		Start:             pm.Run("start"),
+		Image:             "node:20-alpine",
+		User:              "node",
+		Runtime:           "node",
+	}
+	switch {
+	case framework == "NestJS":
+		data.Entrypoint = "dist/main.js"
+	case data.TypeScript:
+		data.Entrypoint = "dist/server.js"
+	default:
+		data.Entrypoint = "src/server.js"
+	}
+	if pm.Name == "bun" {
+		data.Image, data.User, data.Runtime = "oven/bun:1-alpine", "bun", "bun"
+	}
+
+	var lockfiles, checks []string
+	for _, lockfile := range pm.Lockfiles {
+		lockfiles = append(lockfiles, lockfile+"*")
+	}
+	data.Lockfiles = strings.Join(lockfiles, " ")
+
+	lint, test, build := opts.scripts()
+	for _, script := range []string{lint, test, build} {
+		if script != "" {
+			checks = append(checks, pm.Run(script))
+		}
+	}
+	if lint != "" {
+		data.Lint = pm.Run(lint)
+	}
+	if test != "" {
+		data.Test = pm.Run(test)
+	}
+	if build != "" {
+		data.Build = pm.Run(build)
+	}
+	data.Check = strings.Join(checks, " && ")
+	return data, nil
+}
+
+// scripts returns the package scripts that lint, test and build the
+// service, empty when it has no such step.
+func (o NodeServiceOptions) scripts() (lint, test, build string) {
+	if o.Linting {
+		lint = "lint"
+	}
+	if optionValue(o.TestingFramework) != "" {
+		test = "test"
+	}
+	if o.TypeScript || o.Framework == "NestJS" {
+		build = "build"
+	}
+	return lint, test, build
+}
+
+// nodeServicePackageJSON returns the package.json of a Node.js service, with
+// the scripts and dependencies of the chosen framework and tools.
+func nodeServicePackageJSON(data nodeServiceTemplateData) (string, error) {
+	scripts := map[string]string{"start": data.Runtime + " " + data.Entrypoint}
+	var dependencies, devDependencies []string
+
+	switch {
+	case data.Framework == "NestJS":
+		scripts["build"] = "nest build"
+		scripts["dev"] = "nest start --watch"
+		dependencies = append(dependencies, "@nestjs/common", "@nestjs/core", "@nestjs/platform-express", "class-validator", "class-transformer", "reflect-metadata", "rxjs")
+		devDependencies = append(devDependencies, "@nestjs/cli", "typescript", "ts-node", "@types/node", "@types/express")
+	case data.TypeScript:
+		scripts["build"] = "tsc -p tsconfig.build.json"
+		scripts["dev"] = "tsx watch src/server.ts"
+		dependencies = append(dependencies, "express", "zod")
+		devDependencies = append(devDependencies, "typescript", "tsx", "@types/node", "@types/express")
+	default:
+		scripts["dev"] = "nodemon src/server.js"
+		dependencies = append(dependencies, "express", "zod")
+		devDependencies = append(devDependencies, "nodemon")
+	}
+
+	switch data.TestingFramework {
+	case "Jest":
+		scripts["test"] = "jest"
+		devDependencies = append(devDependencies, "jest", "supertest")
+		if data.TypeScript {
+			devDependencies = append(devDependencies, "ts-jest", "@types/jest", "@types/supertest")
+		}
+		if data.Framework == "NestJS" {
+			devDependencies = append(devDependencies, "@nestjs/testing")
+		}
+	case "Mocha":
+		scripts["test"] = "mocha"
+		devDependencies = append(devDependencies, "mocha", "supertest")
+		if data.TypeScript {
+			devDependencies = append(devDependencies, "ts-node", "@types/mocha", "@types/supertest")
+		}
+	}
+
+	if data.Linting {
+		scripts["lint"] = "eslint ."
+		devDependencies = append(devDependencies, "eslint", "@eslint/js", "globals")
+		if data.TypeScript {
+			devDependencies = append(devDependencies, "typescript-eslint")
+		}
+	}
+	if data.Check != "" {
+		scripts["check"] = data.Check
+	}
+
+	manifest := map[string]any{
+		"name":            data.PackageName,
+		"version":         "0.1.0",
+		"private":         true,
+		"scripts":         scripts,
+		"dependencies":    nodePackageRanges(dependencies),
+		"devDependencies": nodePackageRanges(devDependencies),
+	}
+	// Encode like updateJSONFile, so installing only adds the packageManager field
+	content, err := marshalJSONFile(manifest)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// nodePackageRanges maps each package to its pinned version range.
+func nodePackageRanges(packages []string) map[string]string {
+	ranges := map[string]string{}
+	for _, pkg := range packages {
+		ranges[pkg] = nodePackageVersions[pkg]
+	}
+	return ranges
+}
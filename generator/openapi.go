@@ -0,0 +1,417 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OpenAPISpec is the part of an OpenAPI 3 document the generators use: the
+// component schemas and the operations. Schemas and operations keep the
+// order they are written in, so generated code follows the document.
+type OpenAPISpec struct {
+	Title   string
+	Version string
+
+	// Schemas are the component schemas, keyed by name, listed in
+	// SchemaNames in document order.
+	Schemas     map[string]*OpenAPISchema
+	SchemaNames []string
+
+	Operations []OpenAPIOperation
+}
+
+// OpenAPISchema is a JSON schema as used by OpenAPI. References to component
+// schemas are kept in Ref rather than resolved.
+type OpenAPISchema struct {
+	Ref         string
+	Type        string
+	Format      string
+	Description string
+	Nullable    bool
+	Enum        []any
+	Example     any
+
+	// Properties are keyed by name, listed in PropertyNames in document order.
+	Properties    map[string]*OpenAPISchema
+	PropertyNames []string
+	Required      []string
+
+	Items *OpenAPISchema
+	AllOf []*OpenAPISchema
+}
+
+// OpenAPIOperation is a single method on a path.
+type OpenAPIOperation struct {
+	Method      string // upper case, e.g. GET
+	Path        string
+	OperationID string
+	Summary     string
+	Tag         string // first tag, or "default"
+	Parameters  []OpenAPIParameter
+
+	// RequestBody is the application/json request body schema, if any.
+	RequestBody         *OpenAPISchema
+	RequestBodyRequired bool
+
+	// Status is the first success status code and Response its
+	// application/json schema, nil when the response has no JSON body.
+	Status   int
+	Response *OpenAPISchema
+}
+
+// OpenAPIParameter is a path, query or header parameter.
+type OpenAPIParameter struct {
+	Name     string
+	In       string
+	Required bool
+	Schema   *OpenAPISchema
+}
+
+// openAPIMethods lists the HTTP methods an OpenAPI path item may define, in
+// the order operations are generated.
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// ReadOpenAPI reads and parses the OpenAPI 3 document, YAML or JSON, at path.
+func ReadOpenAPI(path string) (*OpenAPISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := ParseOpenAPI(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ParseOpenAPI parses an OpenAPI 3 document written in YAML or JSON.
+func ParseOpenAPI(data []byte) (*OpenAPISpec, error) {
+	var doc struct {
+		OpenAPI string `yaml:"openapi"`
+		Info    struct {
+			Title   string `yaml:"title"`
+			Version string `yaml:"version"`
+		} `yaml:"info"`
+		Paths      yaml.Node `yaml:"paths"`
+		Components struct {
+			Schemas       yaml.Node                        `yaml:"schemas"`
+			Parameters    map[string]openAPIRawParameter   `yaml:"parameters"`
+			RequestBodies map[string]openAPIRawRequestBody `yaml:"requestBodies"`
+			Responses     map[string]openAPIRawResponse    `yaml:"responses"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, expected 3.x", doc.OpenAPI)
+	}
+
+	spec := &OpenAPISpec{
+		Title:   doc.Info.Title,
+		Version: doc.Info.Version,
+		Schemas: map[string]*OpenAPISchema{},
+	}
+
+	err := eachMapping(&doc.Components.Schemas, func(name string, node *yaml.Node) error {
+		schema := &OpenAPISchema{}
+		if err := node.Decode(schema); err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+		spec.Schemas[name] = schema
+		spec.SchemaNames = append(spec.SchemaNames, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = eachMapping(&doc.Paths, func(path string, node *yaml.Node) error {
+		var item map[string]yaml.Node
+		if err := node.Decode(&item); err != nil {
+			return fmt.Errorf("path %s: %w", path, err)
+		}
+
+		var shared []openAPIRawParameter
+		if params, ok := item["parameters"]; ok {
+			if err := params.Decode(&shared); err != nil {
+				return fmt.Errorf("path %s: %w", path, err)
+			}
+		}
+
+		for _, method := range openAPIMethods {
+			node, ok := item[method]
+			if !ok {
+				continue
+			}
+			var raw openAPIRawOperation
+			if err := node.Decode(&raw); err != nil {
+				return fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			operation, err := raw.resolve(strings.ToUpper(method), path, shared, doc.Components.Parameters, doc.Components.RequestBodies, doc.Components.Responses)
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			spec.Operations = append(spec.Operations, operation)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// RefName returns the component name a schema reference points to, e.g.
+// "Pet" for "#/components/schemas/Pet".
+func (s *OpenAPISchema) RefName() string {
+	return refName(s.Ref)
+}
+
+// Flatten merges the allOf parts of a schema, resolving references, into a
+// single object schema. A schema without allOf is returned as is, and so is
+// the only part of an allOf that adds nothing to it.
+func (spec *OpenAPISpec) Flatten(s *OpenAPISchema) *OpenAPISchema {
+	if len(s.AllOf) == 0 {
+		return s
+	}
+	if len(s.AllOf) == 1 && len(s.Properties) == 0 {
+		return s.AllOf[0]
+	}
+
+	merged := *s
+	merged.AllOf = nil
+	merged.Properties = map[string]*OpenAPISchema{}
+	merged.PropertyNames = nil
+	merged.Required = append([]string{}, s.Required...)
+	if merged.Type == "" {
+		merged.Type = "object"
+	}
+
+	parts := append(append([]*OpenAPISchema{}, s.AllOf...), &OpenAPISchema{Properties: s.Properties, PropertyNames: s.PropertyNames})
+	for _, part := range parts {
+		// Follow references, bounded in case of reference cycles
+		for i := 0; part.Ref != "" && i < 10; i++ {
+			if spec.Schemas[part.RefName()] == nil {
+				break
+			}
+			part = spec.Schemas[part.RefName()]
+		}
+		part = spec.Flatten(part)
+		for _, name := range part.PropertyNames {
+			if _, ok := merged.Properties[name]; !ok {
+				merged.PropertyNames = append(merged.PropertyNames, name)
+			}
+			merged.Properties[name] = part.Properties[name]
+		}
+		merged.Required = append(merged.Required, part.Required...)
+	}
+	return &merged
+}
+
+// IsRequired reports whether the object schema requires property name.
+func (s *OpenAPISchema) IsRequired(name string) bool {
+	for _, required := range s.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalYAML decodes a schema, keeping the order of its properties and
+// accepting both OpenAPI 3.0 types and 3.1 type lists such as
+// [string, "null"].
+func (s *OpenAPISchema) UnmarshalYAML(node *yaml.Node) error {
+	var raw struct {
+		Ref         string           `yaml:"$ref"`
+		Type        yaml.Node        `yaml:"type"`
+		Format      string           `yaml:"format"`
+		Description string           `yaml:"description"`
+		Nullable    bool             `yaml:"nullable"`
+		Enum        []any            `yaml:"enum"`
+		Example     any              `yaml:"example"`
+		Properties  yaml.Node        `yaml:"properties"`
+		Required    []string         `yaml:"required"`
+		Items       *OpenAPISchema   `yaml:"items"`
+		AllOf       []*OpenAPISchema `yaml:"allOf"`
+	}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+
+	*s = OpenAPISchema{
+		Ref:         raw.Ref,
+		Format:      raw.Format,
+		Description: raw.Description,
+		Nullable:    raw.Nullable,
+		Enum:        raw.Enum,
+		Example:     raw.Example,
+		Required:    raw.Required,
+		Items:       raw.Items,
+		AllOf:       raw.AllOf,
+	}
+
+	switch raw.Type.Kind {
+	case yaml.ScalarNode:
+		s.Type = raw.Type.Value
+	case yaml.SequenceNode:
+		for _, t := range raw.Type.Content {
+			if t.Value == "null" {
+				s.Nullable = true
+			} else if s.Type == "" {
+				s.Type = t.Value
+			}
+		}
+	}
+
+	return eachMapping(&raw.Properties, func(name string, node *yaml.Node) error {
+		property := &OpenAPISchema{}
+		if err := node.Decode(property); err != nil {
+			return fmt.Errorf("property %s: %w", name, err)
+		}
+		if s.Properties == nil {
+			s.Properties = map[string]*OpenAPISchema{}
+		}
+		s.Properties[name] = property
+		s.PropertyNames = append(s.PropertyNames, name)
+		return nil
+	})
+}
+
+// openAPIRawOperation is an operation as written in the document, before
+// references to shared components are resolved.
+type openAPIRawOperation struct {
+	OperationID string                        `yaml:"operationId"`
+	Summary     string                        `yaml:"summary"`
+	Tags        []string                      `yaml:"tags"`
+	Parameters  []openAPIRawParameter         `yaml:"parameters"`
+	RequestBody *openAPIRawRequestBody        `yaml:"requestBody"`
+	Responses   map[string]openAPIRawResponse `yaml:"responses"`
+}
+
+type openAPIRawParameter struct {
+	Ref      string         `yaml:"$ref"`
+	Name     string         `yaml:"name"`
+	In       string         `yaml:"in"`
+	Required bool           `yaml:"required"`
+	Schema   *OpenAPISchema `yaml:"schema"`
+}
+
+type openAPIRawRequestBody struct {
+	Ref      string                     `yaml:"$ref"`
+	Required bool                       `yaml:"required"`
+	Content  map[string]openAPIRawMedia `yaml:"content"`
+}
+
+type openAPIRawResponse struct {
+	Ref     string                     `yaml:"$ref"`
+	Content map[string]openAPIRawMedia `yaml:"content"`
+}
+
+type openAPIRawMedia struct {
+	Schema *OpenAPISchema `yaml:"schema"`
+}
+
+// resolve converts a raw operation, looking up referenced parameters,
+// request bodies and responses in the document's components.
+func (raw openAPIRawOperation) resolve(method, path string, shared []openAPIRawParameter, parameters map[string]openAPIRawParameter, bodies map[string]openAPIRawRequestBody, responses map[string]openAPIRawResponse) (OpenAPIOperation, error) {
+	op := OpenAPIOperation{
+		Method:      method,
+		Path:        path,
+		OperationID: raw.OperationID,
+		Summary:     raw.Summary,
+		Tag:         "default",
+		Status:      200,
+	}
+	if len(raw.Tags) > 0 {
+		op.Tag = raw.Tags[0]
+	}
+
+	// Operation parameters override path-level ones with the same name and location
+	seen := map[string]bool{}
+	for _, param := range append(append([]openAPIRawParameter{}, raw.Parameters...), shared...) {
+		if param.Ref != "" {
+			resolved, ok := parameters[refName(param.Ref)]
+			if !ok {
+				return op, fmt.Errorf("unknown parameter %s", param.Ref)
+			}
+			param = resolved
+		}
+		if param.In == "cookie" || seen[param.In+" "+param.Name] {
+			continue
+		}
+		seen[param.In+" "+param.Name] = true
+		op.Parameters = append(op.Parameters, OpenAPIParameter{
+			Name:     param.Name,
+			In:       param.In,
+			Required: param.Required || param.In == "path",
+			Schema:   param.Schema,
+		})
+	}
+
+	if body := raw.RequestBody; body != nil {
+		if body.Ref != "" {
+			resolved, ok := bodies[refName(body.Ref)]
+			if !ok {
+				return op, fmt.Errorf("unknown request body %s", body.Ref)
+			}
+			body = &resolved
+		}
+		if media, ok := body.Content["application/json"]; ok && media.Schema != nil {
+			op.RequestBody = media.Schema
+			op.RequestBodyRequired = body.Required
+		}
+	}
+
+	// Use the lowest success status code declared
+	var codes []int
+	for code := range raw.Responses {
+		if status, err := strconv.Atoi(code); err == nil && status >= 200 && status < 300 {
+			codes = append(codes, status)
+		}
+	}
+	sort.Ints(codes)
+	if len(codes) > 0 {
+		op.Status = codes[0]
+		response := raw.Responses[strconv.Itoa(codes[0])]
+		if response.Ref != "" {
+			resolved, ok := responses[refName(response.Ref)]
+			if !ok {
+				return op, fmt.Errorf("unknown response %s", response.Ref)
+			}
+			response = resolved
+		}
+		if media, ok := response.Content["application/json"]; ok {
+			op.Response = media.Schema
+		}
+	}
+	return op, nil
+}
+
+// refName returns the last segment of a local reference.
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// eachMapping calls fn for every key of a YAML mapping node, in document
+// order. A zero node, such as an absent field, has no keys.
+func eachMapping(node *yaml.Node, fn func(key string, value *yaml.Node) error) error {
+	if node.Kind == 0 {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if err := fn(node.Content[i].Value, node.Content[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// PackageManager describes a JavaScript package manager and the command lines
+// it uses to scaffold projects, install dependencies and run package scripts.
+type PackageManager struct {
+	// Name is the executable name of the package manager, e.g. "npm" or "pnpm".
+	Name string
+
+	// Lockfiles lists the lockfile names the package manager writes, used to
+	// detect which package manager an existing project or workspace uses.
+	Lockfiles []string
+
+	// install holds the arguments that add runtime dependencies.
+	install []string
+
+	// installDev holds the arguments that add development dependencies.
+	installDev []string
+
+	// exec holds the command that runs a binary from a package, e.g. "npx".
+	exec []string
+
+	// cleanInstall holds the arguments that install exactly what the
+	// lockfile records, failing if it is out of date, as CI should.
+	cleanInstall []string
+
+	// productionInstall holds the arguments that clean install the runtime
+	// dependencies only, as production images should.
+	productionInstall []string
+}
+
+// packageManagers lists the supported package managers in order of preference
+// when several of them are available on PATH.
+var packageManagers = []PackageManager{
+	{
+		Name:              "npm",
+		Lockfiles:         []string{"package-lock.json", "npm-shrinkwrap.json"},
+		install:           []string{"install"},
+		installDev:        []string{"install", "--save-dev"},
+		exec:              []string{"npx"},
+		cleanInstall:      []string{"ci"},
+		productionInstall: []string{"ci", "--omit=dev"},
+	},
+	{
+		Name:              "yarn",
+		Lockfiles:         []string{"yarn.lock"},
+		install:           []string{"add"},
+		installDev:        []string{"add", "--dev"},
+		exec:              []string{"yarn"},
+		cleanInstall:      []string{"install", "--frozen-lockfile"},
+		productionInstall: []string{"install", "--frozen-lockfile", "--production"},
+	},
+	{
+		Name:              "pnpm",
+		Lockfiles:         []string{"pnpm-lock.yaml"},
+		install:           []string{"add"},
+		installDev:        []string{"add", "--save-dev"},
+		exec:              []string{"pnpm", "exec"},
+		cleanInstall:      []string{"install", "--frozen-lockfile"},
+		productionInstall: []string{"install", "--frozen-lockfile", "--prod"},
+	},
+	{
+		Name:              "bun",
+		Lockfiles:         []string{"bun.lockb", "bun.lock"},
+		install:           []string{"add"},
+		installDev:        []string{"add", "--dev"},
+		exec:              []string{"bunx"},
+		cleanInstall:      []string{"install", "--frozen-lockfile"},
+		productionInstall: []string{"install", "--frozen-lockfile", "--production"},
+	},
+}
+
+// PackageManagerNames returns the names of all supported package managers.
+func PackageManagerNames() []string {
+	names := make([]string, len(packageManagers))
+	for i, pm := range packageManagers {
+		names[i] = pm.Name
+	}
+	return names
+}
+
+// LookupPackageManager returns the package manager with the given name.
+func LookupPackageManager(name string) (PackageManager, error) {
+	for _, pm := range packageManagers {
+		if pm.Name == strings.ToLower(name) {
+			return pm, nil
+		}
+	}
+	return PackageManager{}, fmt.Errorf("unsupported package manager %q (choose one of %s)", name, strings.Join(PackageManagerNames(), ", "))
+}
+
+// DetectPackageManager picks the package manager to use for a project created
+// inside dir. A lockfile or workspace definition in dir or any of its parents
+// wins, so new projects match the repository they are added to. Otherwise the
+// first supported package manager found on PATH is used, falling back to npm.
+func DetectPackageManager(dir string) PackageManager {
+	if pm, ok := detectPackageManagerFromWorkspace(dir); ok {
+		return pm
+	}
+	for _, pm := range packageManagers {
+		if _, err := exec.LookPath(pm.Name); err == nil {
+			return pm
+		}
+	}
+	return packageManagers[0]
+}
+
+// detectPackageManagerFromWorkspace walks up from dir looking for a lockfile,
+// a pnpm workspace file or a "packageManager" field in package.json.
+func detectPackageManagerFromWorkspace(dir string) (PackageManager, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return PackageManager{}, false
+	}
+
+	for {
+		// The corepack "packageManager" field is the most explicit signal
+		if data, err := os.ReadFile(filepath.Join(dir, "package.json")); err == nil {
+			var manifest struct {
+				PackageManager string `json:"packageManager"`
+			}
+			if json.Unmarshal(data, &manifest) == nil && manifest.PackageManager != "" {
+				name, _, _ := strings.Cut(manifest.PackageManager, "@")
+				if pm, err := LookupPackageManager(name); err == nil {
+					return pm, true
+				}
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "pnpm-workspace.yaml")); err == nil {
+			pm, _ := LookupPackageManager("pnpm")
+			return pm, true
+		}
+
+		for _, pm := range packageManagers {
+			for _, lockfile := range pm.Lockfiles {
+				if _, err := os.Stat(filepath.Join(dir, lockfile)); err == nil {
+					return pm, true
+				}
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return PackageManager{}, false
+		}
+		dir = parent
+	}
+}
+
+// CreateArgs returns the command line that scaffolds a project from the given
+// create-* starter, e.g. "react-app" for create-react-app. Extra arguments are
+// passed through to the starter.
+func (pm PackageManager) CreateArgs(starter string, args ...string) []string {
+	argv := []string{pm.Name, "create", starter}
+	if len(args) > 0 {
+		argv = append(argv, args[0])
+		if pm.Name == "npm" && len(args) > 1 {
+			// npm only forwards flags to the starter after a "--" separator
+			argv = append(argv, "--")
+		}
+		argv = append(argv, args[1:]...)
+	}
+	return argv
+}
+
+// InstallArgs returns the command line that installs packages as runtime dependencies.
+func (pm PackageManager) InstallArgs(packages ...string) []string {
+	argv := append([]string{pm.Name}, pm.install...)
+	return append(argv, packages...)
+}
+
+// InstallDevArgs returns the command line that installs packages as development dependencies.
+func (pm PackageManager) InstallDevArgs(packages ...string) []string {
+	argv := append([]string{pm.Name}, pm.installDev...)
+	return append(argv, packages...)
+}
+
+// ExecArgs returns the command line that runs a binary provided by a package.
+func (pm PackageManager) ExecArgs(binary string, args ...string) []string {
+	argv := append(append([]string{}, pm.exec...), binary)
+	return append(argv, args...)
+}
+
+// Run returns the shell command line that runs a package.json script, for use
+// in generated scripts and documentation.
+func (pm PackageManager) Run(script string) string {
+	return pm.Name + " run " + script
+}
+
+// Install returns the shell command line that installs all dependencies.
+func (pm PackageManager) Install() string {
+	return pm.Name + " install"
+}
+
+// CleanInstall returns the shell command line that installs the dependencies
+// recorded in the lockfile, failing if it does not match package.json.
+func (pm PackageManager) CleanInstall() string {
+	return strings.Join(append([]string{pm.Name}, pm.cleanInstall...), " ")
+}
+
+// ProductionInstall returns the shell command line that installs only the
+// runtime dependencies recorded in the lockfile.
+func (pm PackageManager) ProductionInstall() string {
+	return strings.Join(append([]string{pm.Name}, pm.productionInstall...), " ")
+}
+
+// setPackageScripts merges the given scripts into the "scripts" section of the
+// package.json file at path and records the package manager in use.
+func setPackageScripts(ctx context.Context, path string, pm PackageManager, scripts map[string]string) error {
+	// Pin the package manager for corepack when its version is known
+	version := ""
+	if out, err := exec.CommandContext(ctx, pm.Name, "--version").Output(); err == nil {
+		version = strings.TrimSpace(string(out))
+	}
+
+	return updateJSONFile(path, func(manifest map[string]any) {
+		existing, _ := manifest["scripts"].(map[string]any)
+		if existing == nil {
+			existing = map[string]any{}
+		}
+		for name, command := range scripts {
+			existing[name] = command
+		}
+		manifest["scripts"] = existing
+
+		if version != "" {
+			manifest["packageManager"] = pm.Name + "@" + version
+		}
+	})
+}
+
+// updateJSONFile applies update to the JSON object stored in the file at path,
+// such as package.json or tsconfig.json, and writes it back indented.
+func updateJSONFile(path string, update func(map[string]any)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	update(document)
+
+	data, err = marshalJSONFile(document)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// marshalJSONFile encodes v as the indented content of a JSON file such as
+// package.json, leaving characters like & in scripts unescaped.
+func marshalJSONFile(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
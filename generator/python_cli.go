@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PythonCLIOptions configures CreatePythonCLI.
+type PythonCLIOptions struct {
+	Options
+
+	// Framework is one of PythonCLIFrameworks. Empty means "Typer".
+	Framework string
+
+	// TestingFramework is "unittest", "pytest" or "None".
+	TestingFramework string
+}
+
+// PythonCLIFrameworks lists the libraries a Python CLI can be built with.
+var PythonCLIFrameworks = []string{"Typer", "Click"}
+
+// pythonCLIEntrypoints maps each of PythonCLIFrameworks to the object of
+// cli.py the console script calls.
+var pythonCLIEntrypoints = map[string]string{
+	"Typer": "app",
+	"Click": "cli",
+}
+
+// pythonCLITemplateData is the data the Python CLI templates are rendered
+// with.
+type pythonCLITemplateData struct {
+	Name string
+
+	// Package is the import name of the package in src/.
+	Package string
+
+	// Command is the distribution and console script name.
+	Command string
+
+	Framework string
+
+	// Entry is the object of cli.py the console script calls.
+	Entry string
+
+	TestingFramework string
+}
+
+// CreatePythonCLI creates a src-layout Python package with a Typer or Click
+// command-line interface, example subcommands, a console script declared in
+// pyproject.toml and optional CliRunner tests, in a new project directory
+// below opts.Dir.
+func CreatePythonCLI(ctx context.Context, opts PythonCLIOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+	if err := opts.preflight(ctx, "create-python-cli"); err != nil {
+		return err
+	}
+
+	files, err := PythonCLIFiles(opts)
+	if err != nil {
+		return err
+	}
+	if err := writeProject(projectDir, files); err != nil {
+		return err
+	}
+
+	// Record the answers and the render for `infocusp upgrade`
+	answers := pythonCLIAnswers{Framework: opts.Framework, TestingFramework: opts.TestingFramework}
+	if err := writeManifest(projectDir, "create-python-cli", opts.Name, answers, files); err != nil {
+		return err
+	}
+
+	if err := opts.writeCI(projectDir, pythonCLICIJob(projectDir, opts.Name, opts.TestingFramework)); err != nil {
+		return err
+	}
+	if err := opts.initRepository(ctx, projectDir, "python"); err != nil {
+		return err
+	}
+
+	// Success message for the project
+	opts.printf("Python CLI '%s' created successfully!\n", opts.Name)
+	return nil
+}
+
+// PythonCLIFiles renders the files of a Python CLI project without writing
+// them to disk.
+func PythonCLIFiles(opts PythonCLIOptions) (Files, error) {
+	framework := opts.Framework
+	if framework == "" {
+		framework = "Typer"
+	}
+	entry, ok := pythonCLIEntrypoints[framework]
+	if !ok {
+		return nil, fmt.Errorf("unsupported framework %q (choose one of %s)", opts.Framework, strings.Join(PythonCLIFrameworks, ", "))
+	}
+
+	data := pythonCLITemplateData{
+		Name:             opts.Name,
+		Package:          strings.ToLower(pythonIdentifier(opts.Name)),
+		Command:          kubernetesName(opts.Name),
+		Framework:        framework,
+		Entry:            entry,
+		TestingFramework: optionValue(opts.TestingFramework),
+	}
+	files, err := renderTemplates("python-cli/project", data)
+	if err != nil {
+		return nil, err
+	}
+	if data.TestingFramework == "" {
+		return files, nil
+	}
+
+	tests, err := renderTemplates("python-cli/tests", data)
+	if err != nil {
+		return nil, err
+	}
+	for path, content := range tests {
+		files[path] = content
+	}
+	return files, nil
+}
+
+// pythonCLICIJob describes the jobs of a Python CLI, which is installed from
+// pyproject.toml rather than requirements.txt before being tested.
+func pythonCLICIJob(projectDir, name, testingFramework string) ciJob {
+	job := pythonCIJob(projectDir, ".", name, testingFramework)
+	install := "pip install -e ."
+	switch testingFramework {
+	case "pytest":
+		install = `pip install -e ".[dev]"`
+	case "unittest":
+		job.Test = "python -m unittest discover -s tests"
+	default:
+		job.Test = "python -m compileall -q src"
+	}
+	job.Install, job.GitLabInstall = install, install
+	job.CacheFile = "pyproject.toml"
+	return job
+}
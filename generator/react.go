@@ -0,0 +1,439 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReactOptions configures CreateReactApp.
+type ReactOptions struct {
+	Options
+
+	// Tailwind installs and initializes Tailwind CSS.
+	Tailwind bool
+
+	// Linting installs and initializes ESLint.
+	Linting bool
+
+	// TestingFramework is "Jest", "Mocha" or "None".
+	TestingFramework string
+
+	// TypeScript creates the project from the TypeScript template.
+	TypeScript bool
+
+	// Router is "React Router", "TanStack Router" or "None".
+	Router string
+
+	// StateManagement is "Redux Toolkit", "Zustand" or "None".
+	StateManagement string
+
+	// DataFetching is "TanStack Query", "SWR" or "None".
+	DataFetching string
+
+	// Storybook adds Storybook configuration, a story for the sample
+	// component and scripts to run and build it.
+	Storybook bool
+
+	// UILibrary is "MUI", "shadcn/ui", "Chakra" or "None". shadcn/ui
+	// requires Tailwind and TypeScript.
+	UILibrary string
+
+	// PackageManager creates the project, installs dependencies and is
+	// referenced by the generated scripts and README. The zero value uses
+	// the package manager detected for Dir.
+	PackageManager PackageManager
+}
+
+// reactRouterPackages maps each router option to the package providing it.
+var reactRouterPackages = map[string]string{
+	"React Router":    "react-router-dom",
+	"TanStack Router": "@tanstack/react-router",
+}
+
+// reactStatePackages maps each state-management option to its packages.
+var reactStatePackages = map[string][]string{
+	"Redux Toolkit": {"@reduxjs/toolkit", "react-redux"},
+	"Zustand":       {"zustand"},
+}
+
+// reactDataFetchingPackages maps each data-fetching option to its packages.
+var reactDataFetchingPackages = map[string][]string{
+	"TanStack Query": {"@tanstack/react-query"},
+	"SWR":            {"swr"},
+}
+
+// reactUIPackages maps each component library option to its packages.
+var reactUIPackages = map[string][]string{
+	"MUI":       {"@mui/material", "@emotion/react", "@emotion/styled"},
+	"shadcn/ui": {"class-variance-authority", "clsx", "tailwind-merge", "@radix-ui/react-slot"},
+	"Chakra":    {"@chakra-ui/react", "@emotion/react"},
+}
+
+// reactStorybookVersion pins the Storybook major version the templates target.
+const reactStorybookVersion = "^8"
+
+// reactStorybookFrameworks maps each build tool to the Storybook framework
+// package and presets that reuse its build configuration. The generator builds
+// with create-react-app, which uses webpack 5 under the hood.
+var reactStorybookFrameworks = map[string]struct {
+	Framework string
+	Presets   []string
+}{
+	"create-react-app": {
+		Framework: "@storybook/react-webpack5",
+		Presets:   []string{"@storybook/preset-create-react-app"},
+	},
+}
+
+// reactTemplateData is the data the React templates are rendered with.
+// Options that were not chosen are empty strings.
+type reactTemplateData struct {
+	Name          string
+	TypeScript    bool
+	Router        string
+	RouterPackage string
+	State         string
+	DataFetching  string
+	UI            string
+	Tailwind      bool
+
+	// AppTemplates is set when the sample app templates are rendered, so
+	// other templates can rely on src/providers and the example pages.
+	AppTemplates bool
+
+	// StorybookFramework and StorybookPresets configure Storybook for the
+	// build tool in use.
+	StorybookFramework string
+	StorybookPresets   []string
+
+	// Ext and JSXExt are the extensions of plain modules and of modules
+	// containing JSX, matching the create-react-app template in use.
+	Ext    string
+	JSXExt string
+}
+
+// optionValue normalizes an option chosen from a prompt, mapping "None" to "".
+func optionValue(value string) string {
+	if value == "None" {
+		return ""
+	}
+	return value
+}
+
+// CreateReactApp sets up a React project using the given configurations.
+// It supports the following optional customizations:
+// - Tailwind CSS integration
+// - ESLint setup for linting
+// - Testing frameworks (Jest or Mocha)
+// - TypeScript support
+// - Routing (React Router or TanStack Router)
+// - State management (Redux Toolkit or Zustand)
+// - Data fetching (TanStack Query or SWR)
+// - Component library (MUI, shadcn/ui or Chakra)
+// - Storybook
+// - npm, yarn, pnpm or bun as the package manager
+//
+// The function uses create-react-app through the chosen package manager to
+// initialize the React project in opts.Dir, and conditionally installs and
+// configures Tailwind CSS, ESLint, and the selected testing framework.
+// Choosing a router, store, data-fetching or component library installs it
+// and generates example pages, a store, a query hook and a theme wired into
+// the app.
+//
+// The React app of CreateFrontend is a different, Vite-based project
+// rendered from templates; see frontendFrameworks.
+func CreateReactApp(ctx context.Context, opts ReactOptions) error {
+	projectDir, err := opts.ProjectDir()
+	if err != nil {
+		return err
+	}
+
+	// Resolve the libraries before running anything, so bad options fail fast
+	data, dependencies, err := reactAppTemplateData(opts)
+	if err != nil {
+		return err
+	}
+
+	parentDir := filepath.Dir(projectDir)
+	packageManager := opts.PackageManager
+	if packageManager.Name == "" {
+		packageManager = DetectPackageManager(parentDir)
+	}
+
+	// Make sure Node.js and the package manager are usable before creating anything
+	if err := opts.preflight(ctx, "create-react-skeleton", packageManager.Name); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(parentDir, 0755); err != nil {
+		return err
+	}
+
+	// Determine whether to create the React app with or without TypeScript
+	createArgs := []string{filepath.Base(projectDir)}
+	if opts.TypeScript {
+		createArgs = append(createArgs, "--template", "typescript")
+	}
+
+	// Run the command to create the React project next to where it should live
+	if err := opts.run(ctx, parentDir, packageManager.CreateArgs("react-app", createArgs...)); err != nil {
+		return err
+	}
+
+	// Scripts added to package.json on top of the create-react-app defaults
+	scripts := map[string]string{}
+	checks := []string{}
+
+	// If the user selected Tailwind CSS, install and configure it.
+	// create-react-app builds with PostCSS through Tailwind 3, whose config
+	// and CSS entrypoint are rendered from templates.
+	if opts.Tailwind {
+		opts.printf("Installing Tailwind CSS...\n")
+		tailwindPackages := []string{"tailwindcss@3", "postcss", "autoprefixer"}
+		if data.UI == "shadcn/ui" {
+			tailwindPackages = append(tailwindPackages, "tailwindcss-animate")
+		}
+		if err := opts.run(ctx, projectDir, packageManager.InstallDevArgs(tailwindPackages...)); err != nil {
+			return err
+		}
+
+		files, err := renderTemplates("react/tailwind", data)
+		if err != nil {
+			return err
+		}
+		if err := files.Write(projectDir); err != nil {
+			return err
+		}
+	}
+
+	// If the user selected ESLint, set up linting
+	if opts.Linting {
+		opts.printf("Setting up ESLint...\n")
+		if err := opts.run(ctx, projectDir, packageManager.InstallDevArgs("eslint")); err != nil {
+			return err
+		}
+		if err := opts.run(ctx, projectDir, packageManager.ExecArgs("eslint", "--init")); err != nil {
+			return err
+		}
+		scripts["lint"] = "eslint src"
+		checks = append(checks, packageManager.Run("lint"))
+	}
+
+	// Install the selected testing framework (Jest or Mocha)
+	switch opts.TestingFramework {
+	case "Jest":
+		opts.printf("Setting up Jest...\n")
+		if err := opts.run(ctx, projectDir, packageManager.InstallDevArgs("jest")); err != nil {
+			return err
+		}
+		checks = append(checks, "CI=true "+packageManager.Run("test"))
+	case "Mocha":
+		opts.printf("Setting up Mocha...\n")
+		if err := opts.run(ctx, projectDir, packageManager.InstallDevArgs("mocha")); err != nil {
+			return err
+		}
+		scripts["test:mocha"] = "mocha"
+		checks = append(checks, packageManager.Run("test:mocha"))
+	}
+
+	// Chain every enabled check into a single script for CI and pre-push use
+	if len(checks) > 0 {
+		scripts["check"] = strings.Join(checks, " && ")
+	}
+
+	// Install and wire the chosen router, store, data-fetching and component libraries
+	if data.AppTemplates {
+		opts.printf("Installing %s...\n", strings.Join(dependencies, ", "))
+		if err := opts.run(ctx, projectDir, packageManager.InstallArgs(dependencies...)); err != nil {
+			return err
+		}
+
+		files, err := renderTemplates("react/app", data)
+		if err != nil {
+			return err
+		}
+		if err := files.Write(projectDir); err != nil {
+			return err
+		}
+	}
+
+	// Add Storybook from templates rather than the interactive `storybook init`
+	if opts.Storybook {
+		opts.printf("Setting up Storybook...\n")
+		storybookPackages := []string{"storybook", "@storybook/react", "@storybook/addon-essentials", "@storybook/addon-interactions", data.StorybookFramework}
+		storybookPackages = append(storybookPackages, data.StorybookPresets...)
+		for i, pkg := range storybookPackages {
+			storybookPackages[i] = pkg + "@" + reactStorybookVersion
+		}
+		if err := opts.run(ctx, projectDir, packageManager.InstallDevArgs(storybookPackages...)); err != nil {
+			return err
+		}
+
+		files, err := renderTemplates("react/storybook", data)
+		if err != nil {
+			return err
+		}
+		if err := files.Write(projectDir); err != nil {
+			return err
+		}
+		if err := appendLines(filepath.Join(projectDir, ".gitignore"), "", "# storybook", "storybook-static"); err != nil {
+			return err
+		}
+
+		scripts["storybook"] = "storybook dev -p 6006"
+		scripts["build-storybook"] = "storybook build"
+	}
+
+	// shadcn/ui imports components relative to src, as configured in components.json
+	if data.UI == "shadcn/ui" {
+		err := updateJSONFile(filepath.Join(projectDir, "tsconfig.json"), func(tsconfig map[string]any) {
+			compilerOptions, _ := tsconfig["compilerOptions"].(map[string]any)
+			if compilerOptions == nil {
+				compilerOptions = map[string]any{}
+			}
+			compilerOptions["baseUrl"] = "src"
+			tsconfig["compilerOptions"] = compilerOptions
+		})
+		if err != nil {
+			return fmt.Errorf("updating tsconfig.json: %w", err)
+		}
+	}
+
+	if err := setPackageScripts(ctx, filepath.Join(projectDir, "package.json"), packageManager, scripts); err != nil {
+		return fmt.Errorf("updating package.json scripts: %w", err)
+	}
+
+	// Replace the create-react-app README with instructions for the chosen package manager
+	readme := reactReadme(opts.Name, packageManager, scripts)
+	if err := os.WriteFile(filepath.Join(projectDir, "README.md"), []byte(readme), 0644); err != nil {
+		return err
+	}
+
+	lint, test := opts.ciScripts()
+	if err := opts.writeCI(projectDir, nodeCIJob(projectDir, ".", opts.Name, packageManager, lint, test, "build")); err != nil {
+		return err
+	}
+
+	// Replace the repository create-react-app initialized with the requested one
+	if opts.Git.Init || opts.Git.Remote != "" {
+		if err := os.RemoveAll(filepath.Join(projectDir, ".git")); err != nil {
+			return err
+		}
+		if err := opts.initRepository(ctx, projectDir, "node"); err != nil {
+			return err
+		}
+	}
+
+	// Output a message indicating successful project creation
+	opts.printf("Project '%s' created successfully with %s!\n", opts.Name, packageManager.Name)
+	return nil
+}
+
+// ciScripts returns the package scripts CI runs to lint and test the app,
+// empty when the option is off.
+func (o ReactOptions) ciScripts() (lint, test string) {
+	if o.Linting {
+		lint = "lint"
+	}
+	switch o.TestingFramework {
+	case "Jest":
+		test = "test"
+	case "Mocha":
+		test = "test:mocha"
+	}
+	return lint, test
+}
+
+// reactAppTemplateData validates the library choices in opts and returns the
+// template data for them along with the packages they require.
+func reactAppTemplateData(opts ReactOptions) (reactTemplateData, []string, error) {
+	data := reactTemplateData{
+		Name:         opts.Name,
+		TypeScript:   opts.TypeScript,
+		Router:       optionValue(opts.Router),
+		State:        optionValue(opts.StateManagement),
+		DataFetching: optionValue(opts.DataFetching),
+		UI:           optionValue(opts.UILibrary),
+		Tailwind:     opts.Tailwind,
+		Ext:          "js",
+		JSXExt:       "js",
+	}
+	if opts.TypeScript {
+		data.Ext, data.JSXExt = "ts", "tsx"
+	}
+
+	var dependencies []string
+	if data.Router != "" {
+		pkg, ok := reactRouterPackages[data.Router]
+		if !ok {
+			return data, nil, fmt.Errorf("unsupported router %q", opts.Router)
+		}
+		data.RouterPackage = pkg
+		dependencies = append(dependencies, pkg)
+	}
+	if data.State != "" {
+		pkgs, ok := reactStatePackages[data.State]
+		if !ok {
+			return data, nil, fmt.Errorf("unsupported state management library %q", opts.StateManagement)
+		}
+		dependencies = append(dependencies, pkgs...)
+	}
+	if data.DataFetching != "" {
+		pkgs, ok := reactDataFetchingPackages[data.DataFetching]
+		if !ok {
+			return data, nil, fmt.Errorf("unsupported data-fetching library %q", opts.DataFetching)
+		}
+		dependencies = append(dependencies, pkgs...)
+	}
+	if opts.Storybook {
+		framework := reactStorybookFrameworks["create-react-app"]
+		data.StorybookFramework = framework.Framework
+		data.StorybookPresets = framework.Presets
+	}
+	if data.UI != "" {
+		pkgs, ok := reactUIPackages[data.UI]
+		if !ok {
+			return data, nil, fmt.Errorf("unsupported component library %q", opts.UILibrary)
+		}
+		if data.UI == "shadcn/ui" && (!opts.Tailwind || !opts.TypeScript) {
+			return data, nil, fmt.Errorf("shadcn/ui requires both Tailwind CSS and TypeScript")
+		}
+		dependencies = append(dependencies, pkgs...)
+	}
+	data.AppTemplates = len(dependencies) > 0
+	return data, dependencies, nil
+}
+
+// reactReadme renders the README of a generated React project, with every
+// command spelled for the given package manager.
+func reactReadme(projectName string, packageManager PackageManager, scripts map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", projectName)
+	fmt.Fprintf(&b, "This project was generated by the Infocusp Projects CLI and uses **%s**.\n\n", packageManager.Name)
+	b.WriteString("## Getting Started\n\n")
+	b.WriteString("```bash\n")
+	fmt.Fprintf(&b, "%s\n", packageManager.Install())
+	fmt.Fprintf(&b, "%s\n", packageManager.Run("start"))
+	b.WriteString("```\n\n")
+	b.WriteString("## Available Scripts\n\n")
+	fmt.Fprintf(&b, "- `%s`: start the development server\n", packageManager.Run("start"))
+	fmt.Fprintf(&b, "- `%s`: build the app for production\n", packageManager.Run("build"))
+	fmt.Fprintf(&b, "- `%s`: run the test suite\n", packageManager.Run("test"))
+	if _, ok := scripts["test:mocha"]; ok {
+		fmt.Fprintf(&b, "- `%s`: run the Mocha tests\n", packageManager.Run("test:mocha"))
+	}
+	if _, ok := scripts["lint"]; ok {
+		fmt.Fprintf(&b, "- `%s`: lint the sources with ESLint\n", packageManager.Run("lint"))
+	}
+	if _, ok := scripts["storybook"]; ok {
+		fmt.Fprintf(&b, "- `%s`: start Storybook on port 6006\n", packageManager.Run("storybook"))
+		fmt.Fprintf(&b, "- `%s`: build a static Storybook\n", packageManager.Run("build-storybook"))
+	}
+	if _, ok := scripts["check"]; ok {
+		fmt.Fprintf(&b, "- `%s`: run every check, as CI does\n", packageManager.Run("check"))
+	}
+	return b.String()
+}
@@ -0,0 +1,441 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Field is a typed attribute of a resource, parsed from "name:type".
+type Field struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// fieldType describes how a resource field type is written in Python.
+type fieldType struct {
+	// Pydantic is the type annotation used in FastAPI models and schemas.
+	Pydantic string
+	// Converter is the callable Flask schemas convert request values with.
+	Converter string
+	// Sample is a Python literal used in generated tests, JSON compatible.
+	Sample string
+	// NeedsDatetime is set when the type requires `import datetime`.
+	NeedsDatetime bool
+}
+
+// fieldTypes lists the field types supported by resources.
+var fieldTypes = map[string]fieldType{
+	"int":      {Pydantic: "int", Converter: "int", Sample: "1"},
+	"float":    {Pydantic: "float", Converter: "float", Sample: "1.5"},
+	"str":      {Pydantic: "str", Converter: "str", Sample: `"example"`},
+	"bool":     {Pydantic: "bool", Converter: "bool", Sample: "True"},
+	"date":     {Pydantic: "datetime.date", Converter: "datetime.date.fromisoformat", Sample: `"2024-01-01"`, NeedsDatetime: true},
+	"datetime": {Pydantic: "datetime.datetime", Converter: "datetime.datetime.fromisoformat", Sample: `"2024-01-01T00:00:00"`, NeedsDatetime: true},
+}
+
+// identifierPattern matches names usable as Python identifiers.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// reservedFieldNames are the names the generated classes use themselves: the
+// parameters of their methods and the members of the Flask schema.
+var reservedFieldNames = map[string]bool{
+	"self": true, "cls": true, "fields": true, "from_dict": true, "to_dict": true,
+}
+
+// ParseFields parses field definitions such as "total:float". Types are
+// int, float, str, bool, date and datetime.
+func ParseFields(definitions []string) ([]Field, error) {
+	fields := make([]Field, 0, len(definitions))
+	seen := map[string]bool{}
+	for _, definition := range definitions {
+		name, typ, ok := strings.Cut(definition, ":")
+		if !ok {
+			return nil, fmt.Errorf("field %q must be written as name:type", definition)
+		}
+		if !identifierPattern.MatchString(name) {
+			return nil, fmt.Errorf("field name %q is not a valid identifier", name)
+		}
+		if pythonKeywords[name] {
+			return nil, fmt.Errorf("field name %q is a Python keyword", name)
+		}
+		if reservedFieldNames[name] {
+			return nil, fmt.Errorf("field name %q is used by the generated classes", name)
+		}
+		if _, ok := fieldTypes[typ]; !ok {
+			return nil, fmt.Errorf("field %q has unsupported type %q (use int, float, str, bool, date or datetime)", name, typ)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("field %q is defined twice", name)
+		}
+		seen[name] = true
+		fields = append(fields, Field{Name: name, Type: typ})
+	}
+	return fields, nil
+}
+
+// Resource is a named set of fields, as given to `infocusp add resource`.
+type Resource struct {
+	Name   string  `json:"name"`
+	Fields []Field `json:"fields"`
+}
+
+// ParseResource parses a resource definition such as
+// "Order id:int total:float": the name followed by its fields.
+func ParseResource(definition string) (Resource, error) {
+	words := strings.Fields(definition)
+	if len(words) < 2 {
+		return Resource{}, fmt.Errorf("resource %q must be written as a name followed by name:type fields", definition)
+	}
+	fields, err := ParseFields(words[1:])
+	if err != nil {
+		return Resource{}, err
+	}
+	return Resource{Name: words[0], Fields: fields}, nil
+}
+
+// ResourceOptions configures AddResource.
+type ResourceOptions struct {
+	// ProjectDir is the root of a project generated by create-fastapi-skeleton
+	// or create-flask-skeleton.
+	ProjectDir string
+
+	// Name is the resource name, e.g. "Order" or "order_item".
+	Name string
+
+	// Fields are the attributes of the resource.
+	Fields []Field
+
+	// Stdout receives progress messages. Nil discards them.
+	Stdout io.Writer
+}
+
+// resourceTemplateData is the data the resource templates are rendered with.
+type resourceTemplateData struct {
+	Name             string // PascalCase class name, e.g. OrderItem
+	Snake            string // snake_case singular, e.g. order_item
+	Plural           string // snake_case plural, e.g. order_items
+	Upper            string // constant name of the sample payload, e.g. ORDER_ITEM
+	URLPath          string // URL prefix, e.g. order-items
+	Stored           string // lookup of one stored resource, e.g. order_items[order_item_id]
+	Params           string // constructor parameters, e.g. id, total
+	Sample           string // Python dict literal with a sample payload
+	TestingFramework string
+	Fields           []resourceField
+}
+
+// resourceField is a Field with its Python spellings resolved.
+type resourceField struct {
+	Name       string
+	PythonType string
+	Converter  string
+}
+
+// DetectStack reports which skeleton generated the project in dir by
+// looking at the framework imported by app/main.py: "fastapi" or "flask".
+func DetectStack(dir string) (string, error) {
+	main, err := os.ReadFile(filepath.Join(dir, "app", "main.py"))
+	if err != nil {
+		return "", fmt.Errorf("%s does not look like a generated Flask or FastAPI project: %w", dir, err)
+	}
+	switch {
+	case strings.Contains(string(main), "from fastapi import"):
+		return "fastapi", nil
+	case strings.Contains(string(main), "from flask import"):
+		return "flask", nil
+	}
+	return "", fmt.Errorf("could not detect the framework used by %s", filepath.Join(dir, "app", "main.py"))
+}
+
+// detectTestingFramework reports the testing framework the project's
+// existing tests use, or "None" when it has no tests directory.
+func detectTestingFramework(dir string) string {
+	test, err := os.ReadFile(filepath.Join(dir, "tests", "test_main.py"))
+	if err != nil {
+		if _, err := os.Stat(filepath.Join(dir, "tests")); err == nil {
+			return "pytest"
+		}
+		return "None"
+	}
+	if strings.Contains(string(test), "import unittest") {
+		return "unittest"
+	}
+	return "pytest"
+}
+
+// AddResource generates a CRUD resource inside an existing Flask or FastAPI
+// project: the model and schema are appended to app/models.py and
+// app/schemas.py, a router (FastAPI) or blueprint (Flask) module is created
+// and registered in app/main.py, and tests are added using the project's
+// testing framework. The resource is recorded in the project's manifest, when
+// it has one, for RecordedResources.
+func AddResource(ctx context.Context, opts ResourceOptions) error {
+	if len(opts.Fields) == 0 {
+		return fmt.Errorf("a resource needs at least one field")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	stack, err := DetectStack(opts.ProjectDir)
+	if err != nil {
+		return err
+	}
+
+	data, err := newResourceTemplateData(opts.Name, opts.Fields)
+	if err != nil {
+		return err
+	}
+	data.TestingFramework = detectTestingFramework(opts.ProjectDir)
+
+	files, err := renderTemplates("resource/"+stack+"/files", data)
+	if err != nil {
+		return err
+	}
+	appends, err := renderTemplates("resource/"+stack+"/append", data)
+	if err != nil {
+		return err
+	}
+
+	// Compute every change and refuse to overwrite an existing resource
+	// before touching anything, so a conflict leaves the project as it was
+	changes := Files{}
+	for _, path := range files.Paths() {
+		_, err := os.Stat(filepath.Join(opts.ProjectDir, filepath.FromSlash(path)))
+		switch {
+		case err == nil && filepath.Base(path) == "__init__.py":
+			continue
+		case err == nil:
+			return fmt.Errorf("%s already exists", path)
+		}
+		changes[path] = files[path]
+	}
+
+	needsDatetime := false
+	for _, field := range opts.Fields {
+		needsDatetime = needsDatetime || fieldTypes[field.Type].NeedsDatetime
+	}
+	for _, path := range appends.Paths() {
+		existing, err := os.ReadFile(filepath.Join(opts.ProjectDir, filepath.FromSlash(path)))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		for _, class := range classPattern.FindAllStringSubmatch(appends[path], -1) {
+			if regexp.MustCompile(`(?m)^class ` + class[1] + `\b`).Match(existing) {
+				return fmt.Errorf("%s already defines class %s", path, class[1])
+			}
+		}
+		changes[path] = appendPython(string(existing), appends[path], needsDatetime)
+	}
+
+	mainPath := filepath.Join(opts.ProjectDir, "app", "main.py")
+	main, err := os.ReadFile(mainPath)
+	if err != nil {
+		return err
+	}
+	registered, err := registerResource(string(main), stack, data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", mainPath, err)
+	}
+	changes["app/main.py"] = registered
+
+	// Record the definition for `infocusp add api-client`
+	if _, err := os.Stat(filepath.Join(opts.ProjectDir, ManifestFile)); err == nil {
+		manifest, err := ReadManifest(opts.ProjectDir)
+		if err != nil {
+			return err
+		}
+		manifest.Resources = append(manifest.Resources, Resource{Name: opts.Name, Fields: opts.Fields})
+		content, err := manifest.marshal()
+		if err != nil {
+			return err
+		}
+		changes[ManifestFile] = string(content)
+	}
+
+	if err := changes.Write(opts.ProjectDir); err != nil {
+		return err
+	}
+
+	if opts.Stdout != nil {
+		fmt.Fprintf(opts.Stdout, "Resource '%s' added to the %s project at /%s.\n", data.Name, stack, data.URLPath)
+	}
+	return nil
+}
+
+// RecordedResources returns the resources `infocusp add resource` added to the
+// project in dir, as recorded in its manifest.
+func RecordedResources(dir string) ([]Resource, error) {
+	if _, err := os.Stat(filepath.Join(dir, ManifestFile)); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s has no %s recording its resources", dir, ManifestFile)
+	}
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Resources) == 0 {
+		return nil, fmt.Errorf("no resources recorded in %s; add them with `infocusp add resource`", filepath.Join(dir, ManifestFile))
+	}
+	return manifest.Resources, nil
+}
+
+// newResourceTemplateData derives the names used by the templates from the
+// resource name and fields.
+func newResourceTemplateData(name string, fields []Field) (resourceTemplateData, error) {
+	words := splitWords(name)
+	if len(words) == 0 || !identifierPattern.MatchString(strings.Join(words, "_")) {
+		return resourceTemplateData{}, fmt.Errorf("resource name %q is not a valid identifier", name)
+	}
+	if snake := strings.Join(words, "_"); pythonKeywords[snake] {
+		return resourceTemplateData{}, fmt.Errorf("resource name %q is a Python keyword", snake)
+	}
+
+	pascal := ""
+	for _, word := range words {
+		pascal += strings.ToUpper(word[:1]) + word[1:]
+	}
+	pluralWords := append(append([]string{}, words[:len(words)-1]...), pluralize(words[len(words)-1]))
+
+	data := resourceTemplateData{
+		Name:    pascal,
+		Snake:   strings.Join(words, "_"),
+		Plural:  strings.Join(pluralWords, "_"),
+		Upper:   strings.ToUpper(strings.Join(words, "_")),
+		URLPath: strings.Join(pluralWords, "-"),
+	}
+	data.Stored = data.Plural + "[" + data.Snake + "_id]"
+
+	params := make([]string, len(fields))
+	samples := make([]string, len(fields))
+	for i, field := range fields {
+		typ := fieldTypes[field.Type]
+		data.Fields = append(data.Fields, resourceField{Name: field.Name, PythonType: typ.Pydantic, Converter: typ.Converter})
+		params[i] = field.Name
+		samples[i] = strconv.Quote(field.Name) + ": " + typ.Sample
+	}
+	data.Params = strings.Join(params, ", ")
+	data.Sample = "{" + strings.Join(samples, ", ") + "}"
+	return data, nil
+}
+
+// splitWords splits a PascalCase, camelCase, snake_case or kebab-case name
+// into lowercase words.
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == ' ':
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		case unicode.IsUpper(r) && len(current) > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			words = append(words, string(current))
+			current = nil
+		}
+		current = append(current, unicode.ToLower(r))
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// pluralize returns the English plural of a lowercase noun using the
+// regular rules, which covers typical resource names.
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
+
+// classPattern matches the top-level class definitions of a Python module.
+var classPattern = regexp.MustCompile(`(?m)^class (\w+)\b`)
+
+// appendPython returns the Python module content existing with code
+// appended, adding `import datetime` at the top of the module when the code
+// needs it.
+func appendPython(existing, code string, needsDatetime bool) string {
+	content := existing
+	if needsDatetime && !regexp.MustCompile(`(?m)^import datetime$`).MatchString(content) {
+		separator := "\n"
+		if !strings.HasPrefix(content, "import ") && !strings.HasPrefix(content, "from ") {
+			separator = "\n\n"
+		}
+		content = "import datetime" + separator + content
+	}
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return content + code
+}
+
+// registerResource returns the app/main.py content with the resource's router
+// or blueprint wired in: the import goes after the last top-level import and
+// the registration right after the last existing registration, or after the
+// app is created.
+func registerResource(content, stack string, data resourceTemplateData) (string, error) {
+	var importLine, registerLine string
+	var anchors []*regexp.Regexp
+	switch stack {
+	case "fastapi":
+		importLine = fmt.Sprintf("from .routers.%s import router as %s_router", data.Plural, data.Plural)
+		registerLine = fmt.Sprintf("app.include_router(%s_router)", data.Plural)
+		anchors = []*regexp.Regexp{regexp.MustCompile(`^app\.include_router\(`), regexp.MustCompile(`^app = FastAPI\(`)}
+	case "flask":
+		importLine = fmt.Sprintf("from .blueprints.%s import bp as %s_bp", data.Plural, data.Plural)
+		registerLine = fmt.Sprintf("app.register_blueprint(%s_bp)", data.Plural)
+		anchors = []*regexp.Regexp{regexp.MustCompile(`^app\.register_blueprint\(`), regexp.MustCompile(`^app = Flask\(`)}
+	}
+
+	lines := strings.Split(content, "\n")
+
+	lastImport := -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "import ") || strings.HasPrefix(line, "from ") {
+			lastImport = i
+		}
+	}
+	lines = insertLine(lines, lastImport+1, importLine)
+
+	for _, anchor := range anchors {
+		last := -1
+		for i, line := range lines {
+			if anchor.MatchString(line) {
+				last = i
+			}
+		}
+		if last >= 0 {
+			// Skip the rest of a multi-line call such as app = FastAPI(\n...\n)
+			for last < len(lines) && strings.Count(strings.Join(lines[:last+1], "\n"), "(") > strings.Count(strings.Join(lines[:last+1], "\n"), ")") {
+				last++
+			}
+			lines = insertLine(lines, last+1, registerLine)
+			return strings.Join(lines, "\n"), nil
+		}
+	}
+	return "", fmt.Errorf("could not find where the app is created")
+}
+
+// insertLine inserts line before index i.
+func insertLine(lines []string, i int, line string) []string {
+	lines = append(lines, "")
+	copy(lines[i+1:], lines[i:])
+	lines[i] = line
+	return lines
+}
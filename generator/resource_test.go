@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseFieldsRejectsReservedNames(t *testing.T) {
+	for _, definition := range []string{"class:str", "from:date", "None:int"} {
+		if _, err := ParseFields([]string{definition}); err == nil || !strings.Contains(err.Error(), "Python keyword") {
+			t.Errorf("ParseFields(%q) = %v, want a Python keyword error", definition, err)
+		}
+	}
+	for _, definition := range []string{"fields:str", "self:int", "cls:int", "to_dict:bool"} {
+		if _, err := ParseFields([]string{definition}); err == nil || !strings.Contains(err.Error(), "used by the generated classes") {
+			t.Errorf("ParseFields(%q) = %v, want a reserved name error", definition, err)
+		}
+	}
+	if _, err := ParseFields([]string{"class_:str", "from_date:date", "field:str"}); err != nil {
+		t.Errorf("ParseFields rejected valid names: %v", err)
+	}
+	if _, err := newResourceTemplateData("Class", []Field{{Name: "id", Type: "int"}}); err == nil {
+		t.Error("newResourceTemplateData accepted a resource named after a Python keyword")
+	}
+}
+
+// newResourceProject writes a FastAPI skeleton and its manifest to a
+// temporary directory.
+func newResourceProject(t *testing.T) string {
+	t.Helper()
+	opts := FastAPIOptions{Options: Options{Name: "shop"}, TestingFramework: "pytest"}
+	files, err := FastAPIFiles(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "shop")
+	if err := writeProject(dir, files); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeManifest(dir, "create-fastapi-skeleton", "shop", fastAPIAnswers{TestingFramework: opts.TestingFramework}, files); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// snapshot reads every file below dir.
+func snapshot(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	contents := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		contents[path] = string(content)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return contents
+}
+
+func TestAddResourceRejectsConflictsWithoutWriting(t *testing.T) {
+	fields := []Field{{Name: "id", Type: "int"}, {Name: "total", Type: "float"}}
+
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, dir string)
+		want    string
+	}{
+		{
+			// The skeleton already defines class Item in app/models.py
+			name: "Item",
+			want: "already defines class Item",
+		},
+		{
+			name: "Order",
+			prepare: func(t *testing.T, dir string) {
+				if err := AddResource(context.Background(), ResourceOptions{ProjectDir: dir, Name: "Order", Fields: fields}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "already exists",
+		},
+		{
+			name: "Invoice",
+			prepare: func(t *testing.T, dir string) {
+				if err := os.WriteFile(filepath.Join(dir, "app", "main.py"), []byte("from fastapi import FastAPI\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: "could not find where the app is created",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newResourceProject(t)
+			if tt.prepare != nil {
+				tt.prepare(t, dir)
+			}
+			before := snapshot(t, dir)
+
+			err := AddResource(context.Background(), ResourceOptions{ProjectDir: dir, Name: tt.name, Fields: fields})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("AddResource = %v, want an error containing %q", err, tt.want)
+			}
+
+			after := snapshot(t, dir)
+			if len(after) != len(before) {
+				t.Errorf("AddResource left %d files, want %d", len(after), len(before))
+			}
+			for path, content := range before {
+				if after[path] != content {
+					t.Errorf("AddResource changed %s", path)
+				}
+			}
+		})
+	}
+}
+
+func TestAddResourceRecordsResources(t *testing.T) {
+	dir := newResourceProject(t)
+	if _, err := RecordedResources(dir); err == nil {
+		t.Error("RecordedResources succeeded before any resource was added")
+	}
+
+	order, err := ParseResource("Order id:int total:float")
+	if err != nil {
+		t.Fatal(err)
+	}
+	customer, err := ParseResource("Customer name:str joined:date")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, resource := range []Resource{order, customer} {
+		if err := AddResource(context.Background(), ResourceOptions{ProjectDir: dir, Name: resource.Name, Fields: resource.Fields}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// An upgrade rewrites the manifest and must keep the resources
+	if _, err := Upgrade(context.Background(), UpgradeOptions{ProjectDir: dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	resources, err := RecordedResources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 2 || resources[0].Name != "Order" || resources[1].Name != "Customer" {
+		t.Fatalf("RecordedResources = %+v, want Order and Customer", resources)
+	}
+	if got := resources[1].Fields; len(got) != 2 || got[1] != (Field{Name: "joined", Type: "date"}) {
+		t.Errorf("Customer fields = %+v", got)
+	}
+
+	spec, err := ResourcesOpenAPI(resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spec.Schemas["Customer"]; !ok {
+		t.Errorf("OpenAPI schemas = %v, want Customer", spec.SchemaNames)
+	}
+}
@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// templateFS holds the file templates of every generator, one directory per
+// stack below templates/.
+//
+//go:embed all:templates
+var templateFS embed.FS
+
+// Template actions use [[ ]] delimiters, so the {{ }} used by JSX, Helm and
+// other generated sources can be written literally.
+const (
+	leftDelim  = "[["
+	rightDelim = "]]"
+)
+
+// templateFuncs are the helper functions available to every template.
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"quote": strconv.Quote,
+}
+
+// renderTemplates renders the template tree below templates/<root> with data.
+//
+// Both file paths and contents are templates. Files ending in ".tmpl" are
+// executed with the suffix stripped, and dropped when they render to blank
+// output, which is how a template opts out depending on the options. Other
+// files are copied verbatim.
+func renderTemplates(root string, data any) (Files, error) {
+	files := Files{}
+	base := path.Join("templates", root)
+
+	err := fs.WalkDir(templateFS, base, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := renderString(strings.TrimPrefix(name, base+"/"), data)
+		if err != nil {
+			return err
+		}
+
+		content, err := templateFS.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		if !strings.HasSuffix(rel, ".tmpl") {
+			files[rel] = string(content)
+			return nil
+		}
+
+		rendered, err := renderTemplate(name, string(content), data)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(rendered) != "" {
+			files[strings.TrimSuffix(rel, ".tmpl")] = rendered
+		}
+		return nil
+	})
+	return files, err
+}
+
+// renderTemplate executes a single named template with data.
+func renderTemplate(name, text string, data any) (string, error) {
+	tmpl, err := template.New(name).Delims(leftDelim, rightDelim).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderString executes an inline template, such as a file path, with data.
+func renderString(text string, data any) (string, error) {
+	if !strings.Contains(text, leftDelim) {
+		return text, nil
+	}
+	return renderTemplate(text, text, data)
+}
@@ -0,0 +1,32 @@
+"""Data hook of the demo: replace load_data to read from your own files,
+database or API, keeping its return type."""
+import pandas as pd
+
+
+def load_data(path):
+    """Return the dataset at path, one row per day, region and product."""
+    return pd.read_csv(path, parse_dates=["date"])
+
+
+def filter_data(frame, regions=None, products=None):
+    """Return the rows of frame in regions and products; None keeps them all."""
+    mask = pd.Series(True, index=frame.index)
+    if regions is not None:
+        mask &= frame["region"].isin(regions)
+    if products is not None:
+        mask &= frame["product"].isin(products)
+    return frame[mask]
+
+
+def summarize(frame):
+    """Return the headline figures of frame."""
+    return {
+        "units": int(frame["units"].sum()),
+        "revenue": float(frame["revenue"].sum()),
+        "days": int(frame["date"].nunique()),
+    }
+
+
+def revenue_by_day(frame):
+    """Return the daily revenue of each region, one column per region."""
+    return frame.pivot_table(index="date", columns="region", values="revenue", aggfunc="sum").fillna(0)
@@ -0,0 +1,33 @@
+"""Model hook of the demo: replace load_model to load your own model. The
+pages only call its predict method, which returns a Prediction."""
+import re
+from dataclasses import dataclass
+
+POSITIVE = {"good", "great", "excellent", "love", "happy", "fast", "easy", "amazing", "helpful", "recommend"}
+NEGATIVE = {"bad", "poor", "terrible", "hate", "slow", "broken", "difficult", "awful", "useless", "refund"}
+
+
+@dataclass(frozen=True)
+class Prediction:
+    label: str
+    # Confidence that the text is positive, from 0 to 1
+    score: float
+
+
+class SentimentModel:
+    """A stand-in model scoring text by counting positive and negative
+    words, so the demo runs without downloading weights."""
+
+    def predict(self, text):
+        words = re.findall(r"[a-z']+", text.lower())
+        positive = sum(word in POSITIVE for word in words)
+        negative = sum(word in NEGATIVE for word in words)
+        if positive == negative:
+            return Prediction("neutral", 0.5)
+        score = positive / (positive + negative)
+        return Prediction("positive" if score > 0.5 else "negative", round(score, 2))
+
+
+def load_model(path=""):
+    """Load the model at path. The sample model needs no file."""
+    return SentimentModel()
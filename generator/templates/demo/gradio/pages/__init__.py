@@ -0,0 +1,2 @@
+"""The pages of the app, each a module with a build() function adding its
+components to the enclosing tab."""
@@ -0,0 +1,17 @@
+"""The data and model hooks cached across reruns and sessions, so pages
+load them once rather than on every interaction."""
+import streamlit as st
+
+from .config import settings
+from .data import load_data
+from .model import load_model
+
+
+@st.cache_data
+def get_data():
+    return load_data(settings.data_path)
+
+
+@st.cache_resource
+def get_model():
+    return load_model(settings.model_path)
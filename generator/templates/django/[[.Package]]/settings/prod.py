@@ -0,0 +1,13 @@
+"""Settings for production, configured through environment variables."""
+import os
+
+from .base import *  # noqa: F401,F403
+
+SECRET_KEY = os.environ["DJANGO_SECRET_KEY"]
+
+ALLOWED_HOSTS = [host for host in os.environ.get("DJANGO_ALLOWED_HOSTS", "").split(",") if host]
+
+CSRF_TRUSTED_ORIGINS = [origin for origin in os.environ.get("DJANGO_CSRF_TRUSTED_ORIGINS", "").split(",") if origin]
+
+SESSION_COOKIE_SECURE = True
+CSRF_COOKIE_SECURE = True
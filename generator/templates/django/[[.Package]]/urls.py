@@ -0,0 +1,8 @@
+"""URL configuration: the admin and the REST API."""
+from django.contrib import admin
+from django.urls import include, path
+
+urlpatterns = [
+    path("admin/", admin.site.urls),
+    path("api/", include("items.urls")),
+]
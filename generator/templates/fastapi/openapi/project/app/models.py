@@ -0,0 +1,3 @@
+# The API contract lives in schemas.py, generated from the OpenAPI document.
+# Define domain models, such as database models, here.
+from pydantic import BaseModel
@@ -0,0 +1,3 @@
+# The served model is loaded by predictor.py, and the API contract lives in
+# schemas.py. Define domain models, such as database models, here.
+from pydantic import BaseModel
@@ -0,0 +1,49 @@
+import os
+
+# Instances passed to the model at once; larger requests are split into batches
+BATCH_SIZE = int(os.environ.get("BATCH_SIZE", "32"))
+
+# Instances accepted in a single request
+MAX_INSTANCES = int(os.environ.get("MAX_INSTANCES", "1024"))
+
+class StubModel:
+    """Stands in for a trained model, so the API and its tests run without
+    weights. It predicts the mean of the features of each instance."""
+
+    def predict(self, batch):
+        return [sum(features) / len(features) for features in batch]
+
+class Predictor:
+    """Wraps a model with the metadata served by /metadata, and predicts in
+    batches of batch_size instances."""
+
+    def __init__(self, model, name, version, n_features=None, batch_size=BATCH_SIZE):
+        self.model = model
+        self.name = name
+        self.version = version
+        self.n_features = n_features
+        self.batch_size = batch_size
+
+    def predict(self, instances):
+        predictions = []
+        for start in range(0, len(instances), self.batch_size):
+            batch = instances[start:start + self.batch_size]
+            predictions.extend(float(prediction) for prediction in self.model.predict(batch))
+        return predictions
+
+def load_predictor():
+    """Load the model saved with joblib at MODEL_PATH, such as a scikit-learn
+    estimator, or the stub model when MODEL_PATH is not set."""
+    path = os.environ.get("MODEL_PATH")
+    if not path:
+        return Predictor(StubModel(), name="stub", version="0.0.0")
+
+    import joblib
+
+    model = joblib.load(path)
+    return Predictor(
+        model,
+        name=os.environ.get("MODEL_NAME", os.path.splitext(os.path.basename(path))[0]),
+        version=os.environ.get("MODEL_VERSION", "unversioned"),
+        n_features=getattr(model, "n_features_in_", None),
+    )
@@ -0,0 +1,35 @@
+from fastapi import APIRouter, Depends, HTTPException, Request
+
+from .predictor import MAX_INSTANCES, Predictor
+from .schemas import Health, Metadata, PredictRequest, PredictResponse
+
+router = APIRouter()
+
+def get_predictor(request: Request) -> Predictor:
+    return request.app.state.predictor
+
+@router.get("/health", response_model=Health)
+def health():
+    return Health(status="ok")
+
+@router.get("/metadata", response_model=Metadata)
+def metadata(predictor: Predictor = Depends(get_predictor)):
+    return Metadata(
+        name=predictor.name,
+        version=predictor.version,
+        n_features=predictor.n_features,
+        batch_size=predictor.batch_size,
+        max_instances=MAX_INSTANCES,
+    )
+
+@router.post("/predict", response_model=PredictResponse)
+def predict(body: PredictRequest, predictor: Predictor = Depends(get_predictor)):
+    instances = [instance.features for instance in body.instances]
+    if predictor.n_features is not None:
+        for i, features in enumerate(instances):
+            if len(features) != predictor.n_features:
+                raise HTTPException(
+                    status_code=422,
+                    detail=f"instance {i} has {len(features)} features, the model expects {predictor.n_features}",
+                )
+    return PredictResponse(predictions=predictor.predict(instances), version=predictor.version)
@@ -0,0 +1,23 @@
+from pydantic import BaseModel, Field
+
+from .predictor import MAX_INSTANCES
+
+class Instance(BaseModel):
+    features: list[float] = Field(min_length=1, examples=[[5.1, 3.5, 1.4, 0.2]])
+
+class PredictRequest(BaseModel):
+    instances: list[Instance] = Field(min_length=1, max_length=MAX_INSTANCES)
+
+class PredictResponse(BaseModel):
+    predictions: list[float]
+    version: str
+
+class Health(BaseModel):
+    status: str
+
+class Metadata(BaseModel):
+    name: str
+    version: str
+    n_features: int | None = None
+    batch_size: int
+    max_instances: int
@@ -0,0 +1,5 @@
+import { ApplicationConfig, provideZoneChangeDetection } from "@angular/core";
+
+export const appConfig: ApplicationConfig = {
+  providers: [provideZoneChangeDetection({ eventCoalescing: true })],
+};
@@ -0,0 +1,18 @@
+import { Component, OnInit, input, signal } from "@angular/core";
+
+@Component({
+  selector: "app-counter",
+  template: `<button class="counter" type="button" (click)="increment()">count is {{ count() }}</button>`,
+})
+export class CounterComponent implements OnInit {
+  readonly initial = input(0);
+  readonly count = signal(0);
+
+  ngOnInit(): void {
+    this.count.set(this.initial());
+  }
+
+  increment(): void {
+    this.count.update((value) => value + 1);
+  }
+}
@@ -0,0 +1,14 @@
+import adapter from "@sveltejs/adapter-auto";
+import { vitePreprocess } from "@sveltejs/vite-plugin-svelte";
+
+/** @type {import('@sveltejs/kit').Config} */
+const config = {
+  preprocess: vitePreprocess(),
+  kit: {
+    // adapter-auto picks the adapter of the platform deploying the app; see
+    // https://svelte.dev/docs/kit/adapters to target a specific one
+    adapter: adapter(),
+  },
+};
+
+export default config;
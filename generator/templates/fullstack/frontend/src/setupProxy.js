@@ -0,0 +1,17 @@
+const { createProxyMiddleware } = require('http-proxy-middleware');
+
+// Forward API calls from the development server to the FastAPI backend, so the
+// app can use relative URLs without CORS. The API is mounted under /api, which
+// is stripped before forwarding, so app routes such as /items keep serving the
+// app on reload. docker-compose.yml points API_PROXY_TARGET at the backend
+// service.
+module.exports = function (app) {
+  app.use(
+    createProxyMiddleware({
+      target: process.env.API_PROXY_TARGET || 'http://localhost:8000',
+      changeOrigin: true,
+      pathFilter: '/api',
+      pathRewrite: { '^/api': '' },
+    })
+  );
+};
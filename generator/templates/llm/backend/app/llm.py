@@ -0,0 +1,54 @@
+"""A client for the OpenAI-compatible chat completions API served by Ollama,
+vLLM, llama.cpp, OpenAI and others."""
+import json
+
+import httpx
+
+
+class LLMError(Exception):
+    """The model server could not be reached or answered with an error."""
+
+
+class LLMClient:
+    def __init__(self, settings):
+        headers = {}
+        if settings.llm_api_key:
+            headers["Authorization"] = f"Bearer {settings.llm_api_key}"
+        self.model = settings.llm_model
+        self._client = httpx.AsyncClient(
+            base_url=settings.llm_base_url.rstrip("/") + "/",
+            headers=headers,
+            timeout=settings.llm_timeout,
+        )
+
+    async def close(self):
+        await self._client.aclose()
+
+    async def complete(self, messages):
+        """Return the model's reply to messages."""
+        try:
+            response = await self._client.post("chat/completions", json={"model": self.model, "messages": messages})
+            response.raise_for_status()
+            return response.json()["choices"][0]["message"]["content"]
+        except (httpx.HTTPError, KeyError, IndexError, ValueError) as err:
+            raise LLMError(f"chat completion failed: {err}") from err
+
+    async def stream(self, messages):
+        """Yield the model's reply to messages as it is generated."""
+        body = {"model": self.model, "messages": messages, "stream": True}
+        try:
+            async with self._client.stream("POST", "chat/completions", json=body) as response:
+                response.raise_for_status()
+                async for line in response.aiter_lines():
+                    # Server-sent events: "data: {json}" lines, ended by "data: [DONE]"
+                    if not line.startswith("data:"):
+                        continue
+                    data = line[len("data:"):].strip()
+                    if data == "[DONE]":
+                        break
+                    choices = json.loads(data).get("choices") or [{}]
+                    delta = choices[0].get("delta", {}).get("content")
+                    if delta:
+                        yield delta
+        except (httpx.HTTPError, ValueError) as err:
+            raise LLMError(f"chat completion failed: {err}") from err
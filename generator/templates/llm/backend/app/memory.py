@@ -0,0 +1,36 @@
+"""Conversation memory, kept in the process. Replace it with a database or
+Redis to share conversations between workers and restarts."""
+import uuid
+
+
+class ConversationMemory:
+    def __init__(self, max_messages):
+        # Latest messages sent back to the model as context, besides the system prompt
+        self.max_messages = max_messages
+        self._conversations = {}
+
+    def create(self, system_prompt):
+        conversation_id = uuid.uuid4().hex
+        self._conversations[conversation_id] = {"system": system_prompt, "messages": []}
+        return conversation_id
+
+    def exists(self, conversation_id):
+        return conversation_id in self._conversations
+
+    def append(self, conversation_id, role, content):
+        self._conversations[conversation_id]["messages"].append({"role": role, "content": content})
+
+    def messages(self, conversation_id):
+        """Return every message of a conversation, starting with the system prompt."""
+        conversation = self._conversations[conversation_id]
+        return [{"role": "system", "content": conversation["system"]}] + conversation["messages"]
+
+    def context(self, conversation_id):
+        """Return the system prompt and the latest messages of a conversation,
+        the context the model answers the last one in."""
+        conversation = self._conversations[conversation_id]
+        latest = conversation["messages"][-self.max_messages:] if self.max_messages > 0 else []
+        return [{"role": "system", "content": conversation["system"]}] + latest
+
+    def delete(self, conversation_id):
+        return self._conversations.pop(conversation_id, None) is not None
@@ -0,0 +1,24 @@
+"""Prompt templates: the .txt files of this directory, with $variables
+substituted when a conversation starts."""
+import datetime
+import string
+from pathlib import Path
+
+PROMPTS_DIR = Path(__file__).resolve().parent
+
+
+class UnknownPrompt(KeyError):
+    pass
+
+
+def names():
+    return sorted(path.stem for path in PROMPTS_DIR.glob("*.txt"))
+
+
+def render(name, **variables):
+    """Render the prompt template name. Unknown $variables are left as is."""
+    path = PROMPTS_DIR / f"{name}.txt"
+    if not name.isidentifier() or not path.is_file():
+        raise UnknownPrompt(name)
+    variables.setdefault("today", datetime.date.today().isoformat())
+    return string.Template(path.read_text()).safe_substitute(variables).strip()
@@ -0,0 +1,93 @@
+import json
+
+from fastapi import APIRouter, HTTPException, Request
+from fastapi.responses import StreamingResponse
+
+from . import prompts
+from .llm import LLMError
+from .schemas import ChatRequest, ChatResponse, Conversation
+
+router = APIRouter()
+
+
+def start_turn(request: Request, chat: ChatRequest):
+    """Record the user's message and return the conversation id and the
+    messages to send to the model."""
+    memory = request.app.state.memory
+    conversation_id = chat.conversation_id
+    if conversation_id is None:
+        name = chat.prompt or request.app.state.settings.default_prompt
+        try:
+            conversation_id = memory.create(prompts.render(name))
+        except prompts.UnknownPrompt:
+            raise HTTPException(status_code=404, detail=f"Unknown prompt {name!r}")
+    elif not memory.exists(conversation_id):
+        raise HTTPException(status_code=404, detail="Conversation not found")
+
+    memory.append(conversation_id, "user", chat.message)
+    return conversation_id, memory.context(conversation_id)
+
+
+@router.get("/health")
+def health():
+    return {"status": "ok"}
+
+
+@router.get("/prompts")
+def list_prompts():
+    return {"prompts": prompts.names()}
+
+
+@router.post("/chat", response_model=ChatResponse)
+async def chat(chat: ChatRequest, request: Request):
+    conversation_id, messages = start_turn(request, chat)
+    try:
+        reply = await request.app.state.llm.complete(messages)
+    except LLMError as err:
+        raise HTTPException(status_code=502, detail=str(err))
+    request.app.state.memory.append(conversation_id, "assistant", reply)
+    return ChatResponse(conversation_id=conversation_id, reply=reply)
+
+
+@router.post("/chat/stream")
+async def chat_stream(chat: ChatRequest, request: Request):
+    """Stream the reply as server-sent events: a "delta" event per chunk,
+    then a "done" event, or an "error" event when the model fails."""
+    conversation_id, messages = start_turn(request, chat)
+    llm, memory = request.app.state.llm, request.app.state.memory
+
+    async def events():
+        reply = []
+        try:
+            async for delta in llm.stream(messages):
+                reply.append(delta)
+                yield sse("delta", {"content": delta})
+        except LLMError as err:
+            yield sse("error", {"detail": str(err)})
+            return
+        memory.append(conversation_id, "assistant", "".join(reply))
+        yield sse("done", {"conversation_id": conversation_id})
+
+    return StreamingResponse(
+        events(),
+        media_type="text/event-stream",
+        headers={"Cache-Control": "no-cache", "X-Conversation-Id": conversation_id},
+    )
+
+
+@router.get("/conversations/{conversation_id}", response_model=Conversation)
+def get_conversation(conversation_id: str, request: Request):
+    memory = request.app.state.memory
+    if not memory.exists(conversation_id):
+        raise HTTPException(status_code=404, detail="Conversation not found")
+    return Conversation(id=conversation_id, messages=memory.messages(conversation_id))
+
+
+@router.delete("/conversations/{conversation_id}", status_code=204)
+def delete_conversation(conversation_id: str, request: Request):
+    if not request.app.state.memory.delete(conversation_id):
+        raise HTTPException(status_code=404, detail="Conversation not found")
+
+
+def sse(event, data):
+    return f"event: {event}\ndata: {json.dumps(data)}\n\n"
@@ -0,0 +1,26 @@
+from typing import Literal
+
+from pydantic import BaseModel, Field
+
+
+class ChatRequest(BaseModel):
+    message: str = Field(min_length=1)
+    # Continues a conversation; a new one is started when omitted
+    conversation_id: str | None = None
+    # Name of a prompt template in app/prompts, for new conversations
+    prompt: str | None = None
+
+
+class ChatResponse(BaseModel):
+    conversation_id: str
+    reply: str
+
+
+class Message(BaseModel):
+    role: Literal["system", "user", "assistant"]
+    content: str
+
+
+class Conversation(BaseModel):
+    id: str
+    messages: list[Message]
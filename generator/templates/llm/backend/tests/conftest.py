@@ -0,0 +1,71 @@
+import json
+import threading
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+
+import pytest
+from fastapi.testclient import TestClient
+
+from app.config import Settings
+from app.main import create_app
+
+
+class StubModelHandler(BaseHTTPRequestHandler):
+    def do_POST(self):
+        body = json.loads(self.rfile.read(int(self.headers["Content-Length"])))
+        self.server.requests.append({"path": self.path, "headers": dict(self.headers), "body": body})
+        if self.server.status != 200:
+            self.send_error(self.server.status)
+            return
+
+        if body.get("stream"):
+            self.send_response(200)
+            self.send_header("Content-Type", "text/event-stream")
+            self.end_headers()
+            for chunk in self.server.chunks:
+                event = {"choices": [{"index": 0, "delta": {"content": chunk}}]}
+                self.wfile.write(f"data: {json.dumps(event)}\n\n".encode())
+            self.wfile.write(b"data: [DONE]\n\n")
+            return
+
+        message = {"role": "assistant", "content": "".join(self.server.chunks)}
+        payload = json.dumps({"choices": [{"index": 0, "message": message}]}).encode()
+        self.send_response(200)
+        self.send_header("Content-Type", "application/json")
+        self.send_header("Content-Length", str(len(payload)))
+        self.end_headers()
+        self.wfile.write(payload)
+
+    def log_message(self, format, *args):
+        pass
+
+
+class StubModelServer(ThreadingHTTPServer):
+    """A stand-in for Ollama: an OpenAI-compatible chat completions server
+    answering every request with the same chunks, and recording the requests."""
+
+    def __init__(self):
+        super().__init__(("127.0.0.1", 0), StubModelHandler)
+        self.chunks = ["Hello", ", ", "world!"]
+        self.status = 200
+        self.requests = []
+
+    @property
+    def base_url(self):
+        return f"http://127.0.0.1:{self.server_address[1]}/v1"
+
+
+@pytest.fixture
+def model_server():
+    server = StubModelServer()
+    threading.Thread(target=server.serve_forever, daemon=True).start()
+    yield server
+    server.shutdown()
+    server.server_close()
+
+
+@pytest.fixture
+def client(model_server):
+    settings = Settings(llm_base_url=model_server.base_url, llm_model="stub-model", llm_api_key="test-key", max_history_messages=4)
+    # Entering the client runs the lifespan, which creates the model client
+    with TestClient(create_app(settings)) as client:
+        yield client
@@ -0,0 +1,110 @@
+import json
+
+
+def parse_events(body):
+    """Parse a server-sent events body into (event, data) pairs."""
+    events = []
+    for block in body.strip().split("\n\n"):
+        fields = dict(line.split(": ", 1) for line in block.splitlines())
+        events.append((fields["event"], json.loads(fields["data"])))
+    return events
+
+
+def test_health(client):
+    response = client.get("/health")
+    assert response.status_code == 200
+    assert response.json() == {"status": "ok"}
+
+
+def test_chat_replies_with_the_model_answer(client, model_server):
+    response = client.post("/chat", json={"message": "Hi"})
+
+    assert response.status_code == 200
+    assert response.json()["reply"] == "Hello, world!"
+    request = model_server.requests[-1]
+    assert request["path"] == "/v1/chat/completions"
+    assert request["headers"]["Authorization"] == "Bearer test-key"
+    assert request["body"]["model"] == "stub-model"
+    assert request["body"]["messages"][0]["role"] == "system"
+    assert request["body"]["messages"][-1] == {"role": "user", "content": "Hi"}
+
+
+def test_chat_uses_the_requested_prompt(client, model_server):
+    client.post("/chat", json={"message": "Hi", "prompt": "concise"})
+
+    assert "three sentences" in model_server.requests[-1]["body"]["messages"][0]["content"]
+
+
+def test_chat_rejects_unknown_prompts(client):
+    response = client.post("/chat", json={"message": "Hi", "prompt": "../secrets"})
+    assert response.status_code == 404
+
+
+def test_chat_remembers_the_conversation(client, model_server):
+    conversation_id = client.post("/chat", json={"message": "My name is Ada."}).json()["conversation_id"]
+    client.post("/chat", json={"message": "What is my name?", "conversation_id": conversation_id})
+
+    messages = model_server.requests[-1]["body"]["messages"]
+    assert [m["role"] for m in messages] == ["system", "user", "assistant", "user"]
+    assert messages[1]["content"] == "My name is Ada."
+
+
+def test_chat_sends_the_latest_messages_only(client, model_server):
+    conversation_id = client.post("/chat", json={"message": "1"}).json()["conversation_id"]
+    for message in ["2", "3"]:
+        client.post("/chat", json={"message": message, "conversation_id": conversation_id})
+
+    # The system prompt and the 4 latest messages, out of 5
+    messages = model_server.requests[-1]["body"]["messages"]
+    assert messages[0]["role"] == "system"
+    assert [m["content"] for m in messages[1:]] == ["Hello, world!", "2", "Hello, world!", "3"]
+
+
+def test_chat_rejects_unknown_conversations(client):
+    response = client.post("/chat", json={"message": "Hi", "conversation_id": "missing"})
+    assert response.status_code == 404
+
+
+def test_chat_reports_model_server_errors(client, model_server):
+    model_server.status = 500
+
+    response = client.post("/chat", json={"message": "Hi"})
+    assert response.status_code == 502
+
+
+def test_chat_stream_sends_the_reply_in_chunks(client, model_server):
+    response = client.post("/chat/stream", json={"message": "Hi"})
+
+    assert response.status_code == 200
+    assert response.headers["content-type"].startswith("text/event-stream")
+    events = parse_events(response.text)
+    assert [data["content"] for event, data in events if event == "delta"] == ["Hello", ", ", "world!"]
+    assert events[-1] == ("done", {"conversation_id": response.headers["x-conversation-id"]})
+    assert model_server.requests[-1]["body"]["stream"] is True
+
+
+def test_chat_stream_remembers_the_reply(client):
+    response = client.post("/chat/stream", json={"message": "Hi"})
+    conversation_id = response.headers["x-conversation-id"]
+
+    conversation = client.get(f"/conversations/{conversation_id}").json()
+    assert conversation["messages"][-1] == {"role": "assistant", "content": "Hello, world!"}
+
+
+def test_chat_stream_reports_model_server_errors(client, model_server):
+    model_server.status = 503
+
+    response = client.post("/chat/stream", json={"message": "Hi"})
+    assert parse_events(response.text)[-1][0] == "error"
+
+
+def test_delete_conversation(client):
+    conversation_id = client.post("/chat", json={"message": "Hi"}).json()["conversation_id"]
+
+    assert client.delete(f"/conversations/{conversation_id}").status_code == 204
+    assert client.get(f"/conversations/{conversation_id}").status_code == 404
+
+
+def test_list_prompts(client):
+    response = client.get("/prompts")
+    assert response.json() == {"prompts": ["assistant", "concise"]}
@@ -0,0 +1,37 @@
+from app import prompts
+from app.memory import ConversationMemory
+
+
+def test_memory_keeps_the_system_prompt_and_latest_messages():
+    memory = ConversationMemory(max_messages=2)
+    conversation_id = memory.create("Be brief.")
+    for content in ["a", "b", "c"]:
+        memory.append(conversation_id, "user", content)
+
+    assert memory.context(conversation_id) == [
+        {"role": "system", "content": "Be brief."},
+        {"role": "user", "content": "b"},
+        {"role": "user", "content": "c"},
+    ]
+    assert len(memory.messages(conversation_id)) == 4
+
+
+def test_memory_without_history_sends_the_system_prompt_only():
+    memory = ConversationMemory(max_messages=0)
+    conversation_id = memory.create("Be brief.")
+    memory.append(conversation_id, "user", "a")
+
+    assert memory.context(conversation_id) == [{"role": "system", "content": "Be brief."}]
+
+
+def test_memory_delete():
+    memory = ConversationMemory(max_messages=2)
+    conversation_id = memory.create("Be brief.")
+
+    assert memory.delete(conversation_id)
+    assert not memory.exists(conversation_id)
+    assert not memory.delete(conversation_id)
+
+
+def test_prompts_substitute_variables():
+    assert "Today is 2024-01-31." in prompts.render("assistant", today="2024-01-31")
@@ -0,0 +1,55 @@
+import { afterEach, describe, expect, it, vi } from "vitest";
+import { parseEvents, streamChat, type ChatEvent } from "./chat";
+
+async function* fromArray(chunks: string[]) {
+  yield* chunks;
+}
+
+async function collect(events: AsyncIterable<ChatEvent>) {
+  const collected: ChatEvent[] = [];
+  for await (const event of events) {
+    collected.push(event);
+  }
+  return collected;
+}
+
+describe("parseEvents", () => {
+  it("parses events split across chunks", async () => {
+    const events = await collect(
+      parseEvents(fromArray(['event: delta\ndata: {"cont', 'ent": "Hi"}\n\nevent: done\n', 'data: {"conversation_id": "c1"}\n\n'])),
+    );
+
+    expect(events).toEqual([
+      { event: "delta", data: { content: "Hi" } },
+      { event: "done", data: { conversation_id: "c1" } },
+    ]);
+  });
+
+  it("waits for the end of an event", async () => {
+    expect(await collect(parseEvents(fromArray(['event: delta\ndata: {"content": "Hi"}\n'])))).toEqual([]);
+  });
+});
+
+describe("streamChat", () => {
+  afterEach(() => {
+    vi.unstubAllGlobals();
+  });
+
+  it("posts the message and yields the streamed events", async () => {
+    const body = 'event: delta\ndata: {"content": "Hello"}\n\nevent: done\ndata: {"conversation_id": "c1"}\n\n';
+    const fetch = vi.fn().mockResolvedValue(new Response(body, { headers: { "Content-Type": "text/event-stream" } }));
+    vi.stubGlobal("fetch", fetch);
+
+    const events = await collect(streamChat("Hi", "c1"));
+
+    expect(events.map((event) => event.event)).toEqual(["delta", "done"]);
+    expect(fetch).toHaveBeenCalledWith("/api/chat/stream", expect.objectContaining({ method: "POST" }));
+    expect(JSON.parse(fetch.mock.calls[0][1].body)).toEqual({ message: "Hi", conversation_id: "c1" });
+  });
+
+  it("throws when the request fails", async () => {
+    vi.stubGlobal("fetch", vi.fn().mockResolvedValue(new Response("", { status: 404 })));
+
+    await expect(collect(streamChat("Hi"))).rejects.toThrow("status 404");
+  });
+});
@@ -0,0 +1,72 @@
+export interface ChatMessage {
+  role: "user" | "assistant";
+  content: string;
+}
+
+export type ChatEvent =
+  | { event: "delta"; data: { content: string } }
+  | { event: "done"; data: { conversation_id: string } }
+  | { event: "error"; data: { detail: string } };
+
+// Calls go through the development server's proxy unless VITE_API_URL is set
+const API_URL = import.meta.env.VITE_API_URL ?? "/api";
+
+/** Parses server-sent events from text chunks, which may split an event anywhere. */
+export async function* parseEvents(chunks: AsyncIterable<string>): AsyncGenerator<ChatEvent> {
+  let buffer = "";
+  for await (const chunk of chunks) {
+    buffer += chunk;
+    let end: number;
+    while ((end = buffer.indexOf("\n\n")) !== -1) {
+      const block = buffer.slice(0, end);
+      buffer = buffer.slice(end + 2);
+
+      let event = "message";
+      let data = "";
+      for (const line of block.split("\n")) {
+        if (line.startsWith("event:")) {
+          event = line.slice("event:".length).trim();
+        } else if (line.startsWith("data:")) {
+          data += line.slice("data:".length).trim();
+        }
+      }
+      if (data) {
+        yield { event, data: JSON.parse(data) } as ChatEvent;
+      }
+    }
+  }
+}
+
+async function* decode(body: ReadableStream<Uint8Array>): AsyncGenerator<string> {
+  const reader = body.getReader();
+  const decoder = new TextDecoder();
+  try {
+    for (;;) {
+      const { done, value } = await reader.read();
+      if (done) {
+        return;
+      }
+      yield decoder.decode(value, { stream: true });
+    }
+  } finally {
+    reader.releaseLock();
+  }
+}
+
+/** Sends a message, continuing conversationId when set, and yields the events of the streamed reply. */
+export async function* streamChat(
+  message: string,
+  conversationId?: string,
+  signal?: AbortSignal,
+): AsyncGenerator<ChatEvent> {
+  const response = await fetch(`${API_URL}/chat/stream`, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({ message, conversation_id: conversationId }),
+    signal,
+  });
+  if (!response.ok || !response.body) {
+    throw new Error(`Chat request failed with status ${response.status}`);
+  }
+  yield* parseEvents(decode(response.body));
+}
@@ -0,0 +1,55 @@
+import { useCallback, useRef, useState } from "react";
+import { streamChat, type ChatMessage } from "../api/chat";
+
+/** Holds a conversation with the backend, appending the reply as it streams in. */
+export function useChat() {
+  const [messages, setMessages] = useState<ChatMessage[]>([]);
+  const [conversationId, setConversationId] = useState<string>();
+  const [streaming, setStreaming] = useState(false);
+  const [error, setError] = useState<string>();
+  const controller = useRef<AbortController>();
+
+  const send = useCallback(
+    async (message: string) => {
+      setError(undefined);
+      setStreaming(true);
+      setMessages((current) => [...current, { role: "user", content: message }, { role: "assistant", content: "" }]);
+      controller.current = new AbortController();
+      const { signal } = controller.current;
+
+      try {
+        for await (const event of streamChat(message, conversationId, signal)) {
+          if (event.event === "delta") {
+            const { content } = event.data;
+            setMessages((current) => {
+              const reply = current[current.length - 1];
+              return [...current.slice(0, -1), { ...reply, content: reply.content + content }];
+            });
+          } else if (event.event === "done") {
+            setConversationId(event.data.conversation_id);
+          } else {
+            setError(event.data.detail);
+          }
+        }
+      } catch (err) {
+        if (!signal.aborted) {
+          setError(err instanceof Error ? err.message : String(err));
+        }
+      } finally {
+        setStreaming(false);
+      }
+    },
+    [conversationId],
+  );
+
+  const stop = useCallback(() => controller.current?.abort(), []);
+
+  const reset = useCallback(() => {
+    stop();
+    setMessages([]);
+    setConversationId(undefined);
+    setError(undefined);
+  }, [stop]);
+
+  return { messages, streaming, error, send, stop, reset };
+}
@@ -0,0 +1,19 @@
+import { defineConfig } from "vite";
+import react from "@vitejs/plugin-react";
+
+// The backend, which the development server proxies /api to
+const target = process.env.API_PROXY_TARGET ?? "http://localhost:8000";
+
+export default defineConfig({
+  plugins: [react()],
+  server: {
+    host: process.env.VITE_HOST,
+    proxy: {
+      "/api": {
+        target,
+        changeOrigin: true,
+        rewrite: (path) => path.replace(/^\/api/, ""),
+      },
+    },
+  },
+});
@@ -0,0 +1,42 @@
+"""Load the YAML configuration of a run, with command-line overrides."""
+from pathlib import Path
+
+import yaml
+
+PROJECT_DIR = Path(__file__).resolve().parent.parent
+DEFAULT_CONFIG = PROJECT_DIR / "configs" / "train.yaml"
+
+
+def load_config(path=DEFAULT_CONFIG, overrides=()):
+    """Read the configuration at path and apply overrides such as
+    "training.epochs=20", whose values are parsed as YAML."""
+    with open(path) as f:
+        config = yaml.safe_load(f) or {}
+    for override in overrides:
+        key, sep, value = override.partition("=")
+        if not sep:
+            raise ValueError(f"override {override!r} is not of the form key=value")
+        set_value(config, key, yaml.safe_load(value))
+    return config
+
+
+def set_value(config, dotted_key, value):
+    """Set the value at a dotted key such as "model.hidden_size"."""
+    *parents, leaf = dotted_key.split(".")
+    node = config
+    for parent in parents:
+        node = node.setdefault(parent, {})
+    node[leaf] = value
+
+
+def flatten(config, prefix=""):
+    """Flatten nested sections into dotted keys, for experiment trackers."""
+    flat = {}
+    for key, value in config.items():
+        name = f"{prefix}{key}"
+        if isinstance(value, dict):
+            flat.update(flatten(value, name + "."))
+        else:
+            flat[name] = value
+    return flat
+
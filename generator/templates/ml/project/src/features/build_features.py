@@ -0,0 +1,39 @@
+"""Turn processed rows into model inputs."""
+import numpy as np
+
+
+class Standardizer:
+    """Scale every feature to zero mean and unit variance, with statistics
+    fitted on the training set only."""
+
+    def fit(self, features):
+        self.mean_ = features.mean(axis=0)
+        std = features.std(axis=0)
+        # Leave constant features unscaled rather than dividing by zero
+        self.std_ = np.where(std == 0, 1, std).astype(features.dtype)
+        return self
+
+    def transform(self, features):
+        return (features - self.mean_) / self.std_
+
+    def fit_transform(self, features):
+        return self.fit(features).transform(features)
+
+
+def split_features(frame):
+    """Return the feature matrix and the label vector of a processed frame."""
+    features = frame.drop(columns=["label"]).to_numpy(dtype=np.float32)
+    labels = frame["label"].to_numpy()
+    return features, labels
+
+
+def save_standardizer(standardizer, path):
+    """Save the fitted statistics next to the model, for predictions."""
+    np.savez(path, mean=standardizer.mean_, std=standardizer.std_)
+
+
+def load_standardizer(path):
+    with np.load(path) as stats:
+        standardizer = Standardizer()
+        standardizer.mean_, standardizer.std_ = stats["mean"], stats["std"]
+    return standardizer
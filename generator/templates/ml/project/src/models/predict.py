@@ -0,0 +1,28 @@
+"""Predict the labels of the rows of a CSV file with the trained model."""
+import argparse
+
+import pandas as pd
+
+from src.config import PROJECT_DIR
+from src.features.build_features import load_standardizer
+from src.models import model as model_lib
+
+MODELS_DIR = PROJECT_DIR / "models"
+
+
+def main(argv=None):
+    parser = argparse.ArgumentParser(description=__doc__)
+    parser.add_argument("input", help="CSV file with the same feature columns as the training data")
+    args = parser.parse_args(argv)
+
+    frame = pd.read_csv(args.input).drop(columns=["label"], errors="ignore")
+    standardizer = load_standardizer(MODELS_DIR / "scaler.npz")
+    model = model_lib.load(MODELS_DIR / model_lib.MODEL_FILE)
+
+    features = standardizer.transform(frame.to_numpy(dtype="float32"))
+    for label in model_lib.predict(model, features):
+        print(label)
+
+
+if __name__ == "__main__":
+    main()
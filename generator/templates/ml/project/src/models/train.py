@@ -0,0 +1,61 @@
+"""Train the model on data/processed, then save it to models/ and its test
+metrics to reports/metrics.json.
+
+Hyperparameters come from configs/train.yaml; override them with --set, e.g.
+`python -m src.models.train --set model.hidden_size=128`.
+"""
+import argparse
+import json
+import random
+
+import numpy as np
+import pandas as pd
+
+from src import tracking
+from src.config import DEFAULT_CONFIG, PROJECT_DIR, load_config
+from src.features.build_features import Standardizer, save_standardizer, split_features
+from src.models import model as model_lib
+
+PROCESSED_DIR = PROJECT_DIR / "data" / "processed"
+MODELS_DIR = PROJECT_DIR / "models"
+METRICS_FILE = PROJECT_DIR / "reports" / "metrics.json"
+
+
+def seed_everything(seed):
+    random.seed(seed)
+    np.random.seed(seed)
+
+
+def main(argv=None):
+    parser = argparse.ArgumentParser(description=__doc__, formatter_class=argparse.RawDescriptionHelpFormatter)
+    parser.add_argument("--config", default=DEFAULT_CONFIG, help="configuration file (default: configs/train.yaml)")
+    parser.add_argument("--set", dest="overrides", action="append", default=[], metavar="KEY=VALUE", help="override a configuration value")
+    args = parser.parse_args(argv)
+
+    config = load_config(args.config, args.overrides)
+    seed_everything(config["seed"])
+
+    train_features, train_labels = split_features(pd.read_csv(PROCESSED_DIR / "train.csv"))
+    test_features, test_labels = split_features(pd.read_csv(PROCESSED_DIR / "test.csv"))
+    standardizer = Standardizer().fit(train_features)
+    train_features = standardizer.transform(train_features)
+    test_features = standardizer.transform(test_features)
+    n_classes = int(max(train_labels.max(), test_labels.max())) + 1
+
+    with tracking.start_run(config):
+        model = model_lib.build_model(config["model"], train_features.shape[1], n_classes, config["seed"])
+        model_lib.fit(model, train_features, train_labels, config, log=tracking.log_metrics)
+        accuracy = float((model_lib.predict(model, test_features) == test_labels).mean())
+        metrics = {"test_accuracy": accuracy}
+        tracking.log_metrics(metrics)
+
+    MODELS_DIR.mkdir(exist_ok=True)
+    model_lib.save(model, MODELS_DIR / model_lib.MODEL_FILE)
+    save_standardizer(standardizer, MODELS_DIR / "scaler.npz")
+    METRICS_FILE.parent.mkdir(parents=True, exist_ok=True)
+    METRICS_FILE.write_text(json.dumps(metrics, indent=2) + "\n")
+    print(f"Test accuracy: {accuracy:.4f}")
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,49 @@
+import numpy as np
+import pandas as pd
+
+from src.features.build_features import Standardizer, load_standardizer, save_standardizer, split_features
+
+
+def test_standardizer_scales_to_zero_mean_and_unit_variance():
+    features = np.array([[1.0, 10.0], [2.0, 20.0], [3.0, 30.0]], dtype=np.float32)
+
+    scaled = Standardizer().fit_transform(features)
+
+    np.testing.assert_allclose(scaled.mean(axis=0), 0, atol=1e-6)
+    np.testing.assert_allclose(scaled.std(axis=0), 1, atol=1e-6)
+    assert scaled.dtype == np.float32
+
+
+def test_standardizer_leaves_constant_features_finite():
+    features = np.array([[1.0, 5.0], [2.0, 5.0]], dtype=np.float32)
+
+    scaled = Standardizer().fit_transform(features)
+
+    assert np.isfinite(scaled).all()
+    np.testing.assert_array_equal(scaled[:, 1], [0, 0])
+
+
+def test_standardizer_uses_training_statistics():
+    standardizer = Standardizer().fit(np.array([[0.0], [2.0]]))
+
+    np.testing.assert_array_equal(standardizer.transform(np.array([[4.0]])), [[3.0]])
+
+
+def test_standardizer_round_trips_through_a_file(tmp_path):
+    standardizer = Standardizer().fit(np.array([[0.0, 1.0], [2.0, 5.0]]))
+    save_standardizer(standardizer, tmp_path / "scaler.npz")
+
+    loaded = load_standardizer(tmp_path / "scaler.npz")
+
+    np.testing.assert_array_equal(loaded.mean_, standardizer.mean_)
+    np.testing.assert_array_equal(loaded.std_, standardizer.std_)
+
+
+def test_split_features_separates_the_label():
+    frame = pd.DataFrame({"a": [1, 2], "b": [3, 4], "label": [0, 1]})
+
+    features, labels = split_features(frame)
+
+    assert features.shape == (2, 2)
+    assert features.dtype == np.float32
+    assert labels.tolist() == [0, 1]
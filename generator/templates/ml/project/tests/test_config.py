@@ -0,0 +1,26 @@
+import pytest
+
+from src.config import DEFAULT_CONFIG, flatten, load_config
+
+
+def test_default_config_loads():
+    config = load_config(DEFAULT_CONFIG)
+
+    assert {"seed", "data", "model", "tracking"} <= config.keys()
+
+
+def test_overrides_are_parsed_as_yaml():
+    config = load_config(DEFAULT_CONFIG, ["seed=7", "data.test_size=0.5", "extra.name=baseline"])
+
+    assert config["seed"] == 7
+    assert config["data"]["test_size"] == 0.5
+    assert config["extra"]["name"] == "baseline"
+
+
+def test_malformed_override_is_rejected():
+    with pytest.raises(ValueError):
+        load_config(DEFAULT_CONFIG, ["seed"])
+
+
+def test_flatten_uses_dotted_keys():
+    assert flatten({"a": 1, "b": {"c": 2, "d": {"e": 3}}}) == {"a": 1, "b.c": 2, "b.d.e": 3}
@@ -0,0 +1,41 @@
+import pandas as pd
+
+from src.data.make_dataset import load_raw, split
+
+DATA_CONFIG = {"n_samples": 200, "n_features": 6, "n_classes": 2, "test_size": 0.25}
+
+
+def test_load_raw_generates_a_synthetic_dataset(tmp_path):
+    frame = load_raw(DATA_CONFIG, seed=0, path=tmp_path / "missing.csv")
+
+    assert frame.shape == (200, 7)
+    assert frame.columns[-1] == "label"
+    assert set(frame["label"]) == {0, 1}
+
+
+def test_load_raw_is_deterministic(tmp_path):
+    first = load_raw(DATA_CONFIG, seed=0, path=tmp_path / "missing.csv")
+    second = load_raw(DATA_CONFIG, seed=0, path=tmp_path / "missing.csv")
+
+    pd.testing.assert_frame_equal(first, second)
+
+
+def test_load_raw_renames_the_last_column_of_a_csv_to_label(tmp_path):
+    path = tmp_path / "dataset.csv"
+    path.write_text("height,width,species\n1.0,2.0,0\n3.0,4.0,1\n")
+
+    frame = load_raw(DATA_CONFIG, seed=0, path=path)
+
+    assert list(frame.columns) == ["height", "width", "label"]
+    assert frame["label"].tolist() == [0, 1]
+
+
+def test_split_sizes_and_stratification(tmp_path):
+    frame = load_raw(DATA_CONFIG, seed=0, path=tmp_path / "missing.csv")
+
+    train, test = split(frame, test_size=0.25, seed=0)
+
+    assert len(train) == 150
+    assert len(test) == 50
+    assert set(train.index).isdisjoint(test.index)
+    assert test["label"].mean() == frame["label"].mean()
@@ -0,0 +1,10 @@
+const { z } = require("zod");
+
+const itemSchema = z.object({
+  name: z.string().min(1),
+  description: z.string().nullish(),
+  price: z.number().nonnegative(),
+  tax: z.number().nonnegative().nullish(),
+});
+
+module.exports = { itemSchema };
@@ -0,0 +1,69 @@
+const { Router } = require("express");
+
+const { validateBody } = require("../middleware/validate");
+const { itemSchema } = require("./item.schema");
+const { ItemStore } = require("./items.store");
+
+// parseItemId reads the :itemId parameter, answering 422 when it is not an integer.
+function parseItemId(req, res) {
+  const itemId = Number(req.params.itemId);
+  if (!Number.isInteger(itemId)) {
+    res.status(422).json({ detail: "itemId must be an integer" });
+    return undefined;
+  }
+  return itemId;
+}
+
+function itemsRouter(store = new ItemStore()) {
+  const router = Router();
+
+  router.post("/", validateBody(itemSchema), (req, res) => {
+    const itemId = store.create(req.body);
+    res.status(201).json({ message: "Item created", item_id: itemId, item: req.body });
+  });
+
+  router.get("/", (_req, res) => {
+    res.json({ items: store.list() });
+  });
+
+  router.get("/:itemId", (req, res) => {
+    const itemId = parseItemId(req, res);
+    if (itemId === undefined) {
+      return;
+    }
+    const item = store.get(itemId);
+    if (!item) {
+      res.status(404).json({ detail: "Item not found" });
+      return;
+    }
+    res.json({ item_id: itemId, item });
+  });
+
+  router.put("/:itemId", validateBody(itemSchema), (req, res) => {
+    const itemId = parseItemId(req, res);
+    if (itemId === undefined) {
+      return;
+    }
+    if (!store.update(itemId, req.body)) {
+      res.status(404).json({ detail: "Item not found" });
+      return;
+    }
+    res.json({ message: "Item updated", item_id: itemId, item: req.body });
+  });
+
+  router.delete("/:itemId", (req, res) => {
+    const itemId = parseItemId(req, res);
+    if (itemId === undefined) {
+      return;
+    }
+    if (!store.delete(itemId)) {
+      res.status(404).json({ detail: "Item not found" });
+      return;
+    }
+    res.json({ message: "Item deleted", item_id: itemId });
+  });
+
+  return router;
+}
+
+module.exports = { itemsRouter };
@@ -0,0 +1,33 @@
+// In-memory storage keeps the example self-contained; replace it with a database.
+class ItemStore {
+  #items = new Map();
+  #nextId = 1;
+
+  list() {
+    return [...this.#items].map(([itemId, item]) => ({ item_id: itemId, item }));
+  }
+
+  get(itemId) {
+    return this.#items.get(itemId);
+  }
+
+  create(item) {
+    const itemId = this.#nextId++;
+    this.#items.set(itemId, item);
+    return itemId;
+  }
+
+  update(itemId, item) {
+    if (!this.#items.has(itemId)) {
+      return false;
+    }
+    this.#items.set(itemId, item);
+    return true;
+  }
+
+  delete(itemId) {
+    return this.#items.delete(itemId);
+  }
+}
+
+module.exports = { ItemStore };
@@ -0,0 +1,15 @@
+// validateBody replaces the request body with its parsed value, or answers
+// 422 with the validation issues.
+function validateBody(schema) {
+  return (req, res, next) => {
+    const result = schema.safeParse(req.body);
+    if (!result.success) {
+      res.status(422).json({ detail: result.error.issues });
+      return;
+    }
+    req.body = result.data;
+    next();
+  };
+}
+
+module.exports = { validateBody };
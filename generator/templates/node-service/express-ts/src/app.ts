@@ -0,0 +1,29 @@
+import express, { type ErrorRequestHandler } from "express";
+
+import { itemsRouter } from "./items/items.router";
+
+// Malformed JSON bodies and unexpected errors are answered with JSON too
+const handleErrors: ErrorRequestHandler = (err, _req, res, next) => {
+  if (res.headersSent) {
+    next(err);
+    return;
+  }
+  const status = typeof err.status === "number" ? err.status : 500;
+  res.status(status).json({ detail: status === 500 ? "Internal Server Error" : err.message });
+};
+
+export function createApp() {
+  const app = express();
+  app.use(express.json());
+
+  app.get("/", (_req, res) => {
+    res.json({ message: "Hello, World!" });
+  });
+  app.get("/health", (_req, res) => {
+    res.json({ status: "ok" });
+  });
+  app.use("/items", itemsRouter());
+
+  app.use(handleErrors);
+  return app;
+}
@@ -0,0 +1,10 @@
+import { z } from "zod";
+
+export const itemSchema = z.object({
+  name: z.string().min(1),
+  description: z.string().nullish(),
+  price: z.number().nonnegative(),
+  tax: z.number().nonnegative().nullish(),
+});
+
+export type Item = z.infer<typeof itemSchema>;
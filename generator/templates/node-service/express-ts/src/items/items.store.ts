@@ -0,0 +1,33 @@
+import type { Item } from "./item.schema";
+
+// In-memory storage keeps the example self-contained; replace it with a database.
+export class ItemStore {
+  private items = new Map<number, Item>();
+  private nextId = 1;
+
+  list(): { item_id: number; item: Item }[] {
+    return [...this.items].map(([itemId, item]) => ({ item_id: itemId, item }));
+  }
+
+  get(itemId: number): Item | undefined {
+    return this.items.get(itemId);
+  }
+
+  create(item: Item): number {
+    const itemId = this.nextId++;
+    this.items.set(itemId, item);
+    return itemId;
+  }
+
+  update(itemId: number, item: Item): boolean {
+    if (!this.items.has(itemId)) {
+      return false;
+    }
+    this.items.set(itemId, item);
+    return true;
+  }
+
+  delete(itemId: number): boolean {
+    return this.items.delete(itemId);
+  }
+}
@@ -0,0 +1,16 @@
+import type { RequestHandler } from "express";
+import type { ZodTypeAny } from "zod";
+
+// validateBody replaces the request body with its parsed value, or answers
+// 422 with the validation issues.
+export function validateBody(schema: ZodTypeAny): RequestHandler {
+  return (req, res, next) => {
+    const result = schema.safeParse(req.body);
+    if (!result.success) {
+      res.status(422).json({ detail: result.error.issues });
+      return;
+    }
+    req.body = result.data;
+    next();
+  };
+}
@@ -0,0 +1,14 @@
+import { Controller, Get } from "@nestjs/common";
+
+@Controller()
+export class AppController {
+  @Get()
+  hello() {
+    return { message: "Hello, World!" };
+  }
+
+  @Get("health")
+  health() {
+    return { status: "ok" };
+  }
+}
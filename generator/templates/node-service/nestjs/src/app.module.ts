@@ -0,0 +1,10 @@
+import { Module } from "@nestjs/common";
+
+import { AppController } from "./app.controller";
+import { ItemsModule } from "./items/items.module";
+
+@Module({
+  imports: [ItemsModule],
+  controllers: [AppController],
+})
+export class AppModule {}
@@ -0,0 +1,13 @@
+import { HttpStatus, type INestApplication, ValidationPipe } from "@nestjs/common";
+
+// configureApp applies the settings shared by the server and the end-to-end
+// tests: request bodies are validated against their DTOs.
+export function configureApp(app: INestApplication): void {
+  app.useGlobalPipes(
+    new ValidationPipe({
+      whitelist: true,
+      forbidNonWhitelisted: true,
+      errorHttpStatusCode: HttpStatus.UNPROCESSABLE_ENTITY,
+    }),
+  );
+}
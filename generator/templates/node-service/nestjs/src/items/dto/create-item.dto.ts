@@ -0,0 +1,20 @@
+import { IsNotEmpty, IsNumber, IsOptional, IsString, Min } from "class-validator";
+
+export class CreateItemDto {
+  @IsString()
+  @IsNotEmpty()
+  name: string;
+
+  @IsOptional()
+  @IsString()
+  description?: string | null;
+
+  @IsNumber()
+  @Min(0)
+  price: number;
+
+  @IsOptional()
+  @IsNumber()
+  @Min(0)
+  tax?: number | null;
+}
@@ -0,0 +1,6 @@
+export class Item {
+  name: string;
+  description?: string | null;
+  price: number;
+  tax?: number | null;
+}
@@ -0,0 +1,39 @@
+import { Body, Controller, Delete, Get, HttpStatus, Param, ParseIntPipe, Post, Put } from "@nestjs/common";
+
+import { CreateItemDto } from "./dto/create-item.dto";
+import { ItemsService } from "./items.service";
+
+const itemIdPipe = new ParseIntPipe({ errorHttpStatusCode: HttpStatus.UNPROCESSABLE_ENTITY });
+
+@Controller("items")
+export class ItemsController {
+  constructor(private readonly itemsService: ItemsService) {}
+
+  @Post()
+  create(@Body() item: CreateItemDto) {
+    const itemId = this.itemsService.create(item);
+    return { message: "Item created", item_id: itemId, item };
+  }
+
+  @Get()
+  findAll() {
+    return { items: this.itemsService.findAll() };
+  }
+
+  @Get(":itemId")
+  findOne(@Param("itemId", itemIdPipe) itemId: number) {
+    return { item_id: itemId, item: this.itemsService.findOne(itemId) };
+  }
+
+  @Put(":itemId")
+  update(@Param("itemId", itemIdPipe) itemId: number, @Body() item: CreateItemDto) {
+    this.itemsService.update(itemId, item);
+    return { message: "Item updated", item_id: itemId, item };
+  }
+
+  @Delete(":itemId")
+  remove(@Param("itemId", itemIdPipe) itemId: number) {
+    this.itemsService.remove(itemId);
+    return { message: "Item deleted", item_id: itemId };
+  }
+}
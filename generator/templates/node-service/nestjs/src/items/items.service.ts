@@ -0,0 +1,39 @@
+import { Injectable, NotFoundException } from "@nestjs/common";
+
+import { CreateItemDto } from "./dto/create-item.dto";
+import { Item } from "./entities/item.entity";
+
+// In-memory storage keeps the example self-contained; replace it with a database.
+@Injectable()
+export class ItemsService {
+  private readonly items = new Map<number, Item>();
+  private nextId = 1;
+
+  create(item: CreateItemDto): number {
+    const itemId = this.nextId++;
+    this.items.set(itemId, { ...item });
+    return itemId;
+  }
+
+  findAll(): { item_id: number; item: Item }[] {
+    return [...this.items].map(([itemId, item]) => ({ item_id: itemId, item }));
+  }
+
+  findOne(itemId: number): Item {
+    const item = this.items.get(itemId);
+    if (!item) {
+      throw new NotFoundException("Item not found");
+    }
+    return item;
+  }
+
+  update(itemId: number, item: CreateItemDto): void {
+    this.findOne(itemId);
+    this.items.set(itemId, { ...item });
+  }
+
+  remove(itemId: number): void {
+    this.findOne(itemId);
+    this.items.delete(itemId);
+  }
+}
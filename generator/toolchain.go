@@ -0,0 +1,277 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Tool is an external program that generators or generated projects use.
+type Tool struct {
+	Name string
+
+	// Commands are the executables that provide the tool, in order of
+	// preference, e.g. pip3 before pip.
+	Commands []string
+
+	// VersionArgs print the tool's version.
+	VersionArgs []string
+
+	// MinVersion is the oldest supported version, e.g. "18.0".
+	MinVersion string
+
+	// Install tells the user how to get the tool.
+	Install string
+}
+
+// tools lists every tool the doctor command checks.
+var tools = []Tool{
+	{Name: "node", Commands: []string{"node"}, VersionArgs: []string{"--version"}, MinVersion: "18.0", Install: "https://nodejs.org/"},
+	{Name: "npm", Commands: []string{"npm"}, VersionArgs: []string{"--version"}, MinVersion: "8.0", Install: "installed with Node.js"},
+	{Name: "yarn", Commands: []string{"yarn"}, VersionArgs: []string{"--version"}, MinVersion: "1.22", Install: "corepack enable"},
+	{Name: "pnpm", Commands: []string{"pnpm"}, VersionArgs: []string{"--version"}, MinVersion: "8.0", Install: "corepack enable"},
+	{Name: "bun", Commands: []string{"bun"}, VersionArgs: []string{"--version"}, MinVersion: "1.0", Install: "https://bun.sh/"},
+	{Name: "python3", Commands: []string{"python3", "python"}, VersionArgs: []string{"--version"}, MinVersion: "3.8", Install: "https://www.python.org/downloads/"},
+	{Name: "pip", Commands: []string{"pip3", "pip"}, VersionArgs: []string{"--version"}, MinVersion: "21.0", Install: "python3 -m ensurepip --upgrade"},
+	{Name: "pip-compile", Commands: []string{"pip-compile"}, VersionArgs: []string{"--version"}, MinVersion: "7.0", Install: "pip install pip-tools"},
+	{Name: "go", Commands: []string{"go"}, VersionArgs: []string{"version"}, MinVersion: "1.22", Install: "https://go.dev/dl/"},
+	{Name: "dvc", Commands: []string{"dvc"}, VersionArgs: []string{"--version"}, MinVersion: "3.0", Install: "pip install dvc"},
+	{Name: "docker", Commands: []string{"docker"}, VersionArgs: []string{"--version"}, MinVersion: "20.10", Install: "https://docs.docker.com/get-docker/"},
+	{Name: "git", Commands: []string{"git"}, VersionArgs: []string{"--version"}, MinVersion: "2.28", Install: "https://git-scm.com/downloads"},
+}
+
+// Tools returns the tools the doctor command checks.
+func Tools() []Tool {
+	return append([]Tool{}, tools...)
+}
+
+// LookupTool returns the tool called name.
+func LookupTool(name string) (Tool, bool) {
+	for _, tool := range tools {
+		if tool.Name == name {
+			return tool, true
+		}
+	}
+	return Tool{}, false
+}
+
+// ToolCheck is the result of looking for a tool.
+type ToolCheck struct {
+	Tool    Tool
+	Path    string // executable found, empty if none
+	Version string // version reported by the tool, empty if unknown
+
+	// Err explains why the tool is unusable: missing, or older than
+	// Tool.MinVersion. It is nil for usable tools.
+	Err error
+}
+
+// versionPattern matches the first dotted version number in a tool's output.
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// CheckTool looks for tool in PATH and checks its version.
+func CheckTool(ctx context.Context, tool Tool) ToolCheck {
+	check := ToolCheck{Tool: tool}
+	for _, command := range tool.Commands {
+		if path, err := exec.LookPath(command); err == nil {
+			check.Path = path
+			break
+		}
+	}
+	if check.Path == "" {
+		check.Err = fmt.Errorf("%s not found in PATH (install: %s)", tool.Name, tool.Install)
+		return check
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, check.Path, tool.VersionArgs...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		check.Err = fmt.Errorf("%s --version failed: %w", tool.Name, err)
+		return check
+	}
+
+	check.Version = versionPattern.FindString(output.String())
+	if check.Version != "" && tool.MinVersion != "" && compareVersions(check.Version, tool.MinVersion) < 0 {
+		check.Err = fmt.Errorf("%s %s is older than the required %s", tool.Name, check.Version, tool.MinVersion)
+	}
+	return check
+}
+
+// compareVersions compares dotted version numbers, returning -1, 0 or 1.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// StackRequirements lists the tools a create command needs.
+type StackRequirements struct {
+	Command string // e.g. create-react-skeleton
+	Stack   string // e.g. React
+
+	// Required tools must all be present to generate the project.
+	Required []string
+
+	// OneOf lists alternatives of which at least one is required, such as
+	// the package managers a React app can be created with.
+	OneOf []string
+
+	// Recommended tools are needed to run, test or ship the generated
+	// project, but not to generate it.
+	Recommended []string
+}
+
+// stackRequirements lists the tools needed by every create command.
+var stackRequirements = []StackRequirements{
+	{Command: "create-react-skeleton", Stack: "React", Required: []string{"node"}, OneOf: PackageManagerNames(), Recommended: []string{"git"}},
+	{Command: "create-frontend", Stack: "Frontend", Required: []string{"node"}, OneOf: PackageManagerNames(), Recommended: []string{"git"}},
+	{Command: "create-fastapi-skeleton", Stack: "FastAPI", Recommended: []string{"python3", "pip", "docker"}},
+	{Command: "create-flask-skeleton", Stack: "Flask", Recommended: []string{"python3", "pip", "docker"}},
+	{Command: "create-django-skeleton", Stack: "Django", Recommended: []string{"python3", "pip", "docker"}},
+	{Command: "create-go-service", Stack: "Go", Recommended: []string{"go", "docker", "git"}},
+	{Command: "create-node-service", Stack: "Node.js", Required: []string{"node"}, OneOf: PackageManagerNames(), Recommended: []string{"docker", "git"}},
+	{Command: "create-python-cli", Stack: "Python CLI", Recommended: []string{"python3", "pip", "git"}},
+	{Command: "create-ml-project", Stack: "Machine learning", Recommended: []string{"python3", "pip", "dvc", "git"}},
+	{Command: "create-llm-app", Stack: "LLM app", Recommended: []string{"python3", "pip", "docker", "git"}},
+	{Command: "create-demo-app", Stack: "Demo app", Recommended: []string{"python3", "pip", "docker", "git"}},
+	{Command: "create-fullstack", Stack: "React + FastAPI", Required: []string{"node"}, OneOf: PackageManagerNames(), Recommended: []string{"python3", "pip", "docker", "git"}},
+}
+
+// Stacks returns the tool requirements of every create command.
+func Stacks() []StackRequirements {
+	return append([]StackRequirements{}, stackRequirements...)
+}
+
+// StackReport says whether a stack can be generated with the tools found.
+type StackReport struct {
+	StackRequirements
+
+	// Missing lists the unusable required tools. The stack is usable when
+	// it is empty.
+	Missing []string
+
+	// Options lists the usable alternatives of OneOf.
+	Options []string
+
+	// Warnings lists the unusable recommended tools.
+	Warnings []string
+}
+
+// Usable reports whether the stack can be generated.
+func (r StackReport) Usable() bool {
+	return len(r.Missing) == 0
+}
+
+// DoctorReport is the state of the toolchain.
+type DoctorReport struct {
+	Tools  []ToolCheck
+	Stacks []StackReport
+}
+
+// Doctor checks every tool and reports which stacks can be generated.
+func Doctor(ctx context.Context) DoctorReport {
+	var report DoctorReport
+	checks := map[string]ToolCheck{}
+	for _, tool := range tools {
+		check := CheckTool(ctx, tool)
+		checks[tool.Name] = check
+		report.Tools = append(report.Tools, check)
+	}
+	for _, requirements := range stackRequirements {
+		report.Stacks = append(report.Stacks, stackReport(requirements, checks))
+	}
+	return report
+}
+
+// stackReport evaluates requirements against the checked tools.
+func stackReport(requirements StackRequirements, checks map[string]ToolCheck) StackReport {
+	report := StackReport{StackRequirements: requirements}
+	for _, name := range requirements.Required {
+		if checks[name].Err != nil {
+			report.Missing = append(report.Missing, name)
+		}
+	}
+	for _, name := range requirements.OneOf {
+		if checks[name].Err == nil {
+			report.Options = append(report.Options, name)
+		}
+	}
+	if len(requirements.OneOf) > 0 && len(report.Options) == 0 {
+		report.Missing = append(report.Missing, "one of "+strings.Join(requirements.OneOf, ", "))
+	}
+	for _, name := range requirements.Recommended {
+		if checks[name].Err != nil {
+			report.Warnings = append(report.Warnings, name)
+		}
+	}
+	return report
+}
+
+// preflight checks the tools the create command is about to use before
+// anything is written: the command's required tools from Stacks, plus
+// chosen, the tools picked among its alternatives. Missing required tools
+// are an error; missing recommended tools are reported as warnings, since
+// the project can still be generated. The CI provider, and the author of the
+// initial commit when a git repository is requested, are checked too.
+func (o Options) preflight(ctx context.Context, command string, chosen ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if o.skipPreflight {
+		return nil
+	}
+	if err := validateCI(o.CI); err != nil {
+		return err
+	}
+	if o.Git.Init || o.Git.Remote != "" {
+		if _, err := o.Git.author(); err != nil {
+			return err
+		}
+	}
+
+	var requirements StackRequirements
+	for _, r := range stackRequirements {
+		if r.Command == command {
+			requirements = r
+		}
+	}
+
+	var problems []string
+	for _, name := range append(append([]string{}, requirements.Required...), chosen...) {
+		tool, _ := LookupTool(name)
+		if check := CheckTool(ctx, tool); check.Err != nil {
+			problems = append(problems, check.Err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("missing tools, run `infocusp doctor` for details:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	for _, name := range requirements.Recommended {
+		tool, _ := LookupTool(name)
+		if check := CheckTool(ctx, tool); check.Err != nil {
+			o.printf("Warning: %v; it is needed to run or ship the project.\n", check.Err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,430 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TemplateVersion is the version of the embedded project templates. It is
+// recorded in generated projects; bump it whenever a template that
+// `infocusp upgrade` regenerates changes.
+const TemplateVersion = 2
+
+// ManifestFile is the file, at the root of a generated project, recording how
+// the project was generated.
+const ManifestFile = ".infocusp.json"
+
+// Manifest records how a project was generated, so that it can be
+// regenerated against newer templates.
+type Manifest struct {
+	// Generator is the create command that generated the project.
+	Generator string `json:"generator"`
+
+	// TemplateVersion is the TemplateVersion the files were rendered with.
+	TemplateVersion int `json:"templateVersion"`
+
+	// Name is the project name the templates were rendered with.
+	Name string `json:"name"`
+
+	// Answers holds the generator's options, in a format of its own.
+	Answers json.RawMessage `json:"answers"`
+
+	// Files is the original render, the base of the 3-way merge of the
+	// next upgrade.
+	Files Files `json:"files"`
+
+	// Resources are the resources `infocusp add resource` added to the
+	// project, in order.
+	Resources []Resource `json:"resources,omitempty"`
+}
+
+// projectRenderers regenerate the files of the projects that record a
+// manifest, from the project name and the recorded answers.
+var projectRenderers = map[string]func(name string, answers json.RawMessage) (Files, error){
+	"create-fastapi-skeleton": func(name string, answers json.RawMessage) (Files, error) {
+		var a fastAPIAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		return FastAPIFiles(FastAPIOptions{
+			Options:          Options{Name: name},
+			TestingFramework: a.TestingFramework,
+			CORSOrigins:      a.CORSOrigins,
+			OpenAPI:          a.OpenAPI,
+			ModelServing:     a.ModelServing,
+			Deploy:           a.Deploy,
+			GoogleCloud:      a.GoogleCloud,
+		})
+	},
+	"create-flask-skeleton": func(name string, answers json.RawMessage) (Files, error) {
+		var a flaskAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		return FlaskFiles(FlaskOptions{Options: Options{Name: name}, TestingFramework: a.TestingFramework, Deploy: a.Deploy, GoogleCloud: a.GoogleCloud})
+	},
+	"create-django-skeleton": func(name string, answers json.RawMessage) (Files, error) {
+		var a djangoAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		return DjangoFiles(DjangoOptions{Options: Options{Name: name}, TestingFramework: a.TestingFramework, Docker: a.Docker, DependencyManager: a.DependencyManager})
+	},
+	"create-node-service": func(name string, answers json.RawMessage) (Files, error) {
+		var a nodeServiceAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		packageManager, err := LookupPackageManager(a.PackageManager)
+		if err != nil {
+			return nil, err
+		}
+		return NodeServiceFiles(NodeServiceOptions{
+			Options:          Options{Name: name},
+			Framework:        a.Framework,
+			TypeScript:       a.TypeScript,
+			Linting:          a.Linting,
+			TestingFramework: a.TestingFramework,
+			Docker:           a.Docker,
+			PackageManager:   packageManager,
+		})
+	},
+	"create-go-service": func(name string, answers json.RawMessage) (Files, error) {
+		var a goServiceAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		return GoServiceFiles(GoServiceOptions{Options: Options{Name: name}, Module: a.Module, Router: a.Router})
+	},
+	"create-ml-project": func(name string, answers json.RawMessage) (Files, error) {
+		var a mlAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		return MLProjectFiles(MLOptions{Options: Options{Name: name}, Framework: a.Framework, Tracking: a.Tracking, DVC: a.DVC})
+	},
+	"create-python-cli": func(name string, answers json.RawMessage) (Files, error) {
+		var a pythonCLIAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		return PythonCLIFiles(PythonCLIOptions{Options: Options{Name: name}, Framework: a.Framework, TestingFramework: a.TestingFramework})
+	},
+	"create-llm-app": func(name string, answers json.RawMessage) (Files, error) {
+		var a llmAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		var packageManager PackageManager
+		if a.PackageManager != "" {
+			var err error
+			if packageManager, err = LookupPackageManager(a.PackageManager); err != nil {
+				return nil, err
+			}
+		}
+		return LLMAppFiles(LLMOptions{Options: Options{Name: name}, Provider: a.Provider, Frontend: a.Frontend, PackageManager: packageManager})
+	},
+	"create-demo-app": func(name string, answers json.RawMessage) (Files, error) {
+		var a demoAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		return DemoAppFiles(DemoAppOptions{Options: Options{Name: name}, Framework: a.Framework})
+	},
+	"create-frontend": func(name string, answers json.RawMessage) (Files, error) {
+		var a frontendAnswers
+		if err := json.Unmarshal(answers, &a); err != nil {
+			return nil, err
+		}
+		packageManager, err := LookupPackageManager(a.PackageManager)
+		if err != nil {
+			return nil, err
+		}
+		return FrontendFiles(FrontendOptions{
+			Options:        Options{Name: name},
+			Framework:      a.Framework,
+			TypeScript:     a.TypeScript,
+			Tailwind:       a.Tailwind,
+			Linting:        a.Linting,
+			UnitTests:      a.UnitTests,
+			E2ETesting:     a.E2ETesting,
+			PackageManager: packageManager,
+		})
+	},
+}
+
+// derivedFiles maps the files that tools write from another file of the
+// project, and that the templates therefore leave out, to that file. An
+// upgrade keeps them while the templates render their source.
+var derivedFiles = map[string]string{
+	"requirements.txt": "requirements.in",
+}
+
+// fastAPIAnswers are the recorded options of create-fastapi-skeleton.
+type fastAPIAnswers struct {
+	TestingFramework string             `json:"testingFramework"`
+	CORSOrigins      []string           `json:"corsOrigins,omitempty"`
+	OpenAPI          *OpenAPISpec       `json:"openapi,omitempty"`
+	ModelServing     bool               `json:"modelServing,omitempty"`
+	Deploy           string             `json:"deploy,omitempty"`
+	GoogleCloud      GoogleCloudOptions `json:"googleCloud,omitempty"`
+}
+
+// flaskAnswers are the recorded options of create-flask-skeleton.
+type flaskAnswers struct {
+	TestingFramework string             `json:"testingFramework"`
+	Deploy           string             `json:"deploy,omitempty"`
+	GoogleCloud      GoogleCloudOptions `json:"googleCloud,omitempty"`
+}
+
+// djangoAnswers are the recorded options of create-django-skeleton.
+type djangoAnswers struct {
+	TestingFramework  string `json:"testingFramework"`
+	Docker            bool   `json:"docker"`
+	DependencyManager string `json:"dependencyManager,omitempty"`
+}
+
+// goServiceAnswers are the recorded options of create-go-service.
+type goServiceAnswers struct {
+	Module string `json:"module,omitempty"`
+	Router string `json:"router,omitempty"`
+}
+
+// nodeServiceAnswers are the recorded options of create-node-service.
+type nodeServiceAnswers struct {
+	Framework        string `json:"framework"`
+	TypeScript       bool   `json:"typescript"`
+	Linting          bool   `json:"linting"`
+	TestingFramework string `json:"testingFramework"`
+	Docker           bool   `json:"docker"`
+	PackageManager   string `json:"packageManager"`
+}
+
+// mlAnswers are the recorded options of create-ml-project.
+type mlAnswers struct {
+	Framework string `json:"framework,omitempty"`
+	Tracking  string `json:"tracking,omitempty"`
+	DVC       bool   `json:"dvc"`
+}
+
+// pythonCLIAnswers are the recorded options of create-python-cli.
+type pythonCLIAnswers struct {
+	Framework        string `json:"framework,omitempty"`
+	TestingFramework string `json:"testingFramework"`
+}
+
+// llmAnswers are the recorded options of create-llm-app.
+type llmAnswers struct {
+	Provider       string `json:"provider,omitempty"`
+	Frontend       bool   `json:"frontend"`
+	PackageManager string `json:"packageManager,omitempty"`
+}
+
+// frontendAnswers are the recorded options of create-frontend.
+type frontendAnswers struct {
+	Framework      string `json:"framework"`
+	TypeScript     bool   `json:"typescript"`
+	Tailwind       bool   `json:"tailwind"`
+	Linting        bool   `json:"linting"`
+	UnitTests      bool   `json:"unitTests"`
+	E2ETesting     string `json:"e2eTesting"`
+	PackageManager string `json:"packageManager"`
+}
+
+// demoAnswers are the recorded options of create-demo-app.
+type demoAnswers struct {
+	Framework string `json:"framework,omitempty"`
+}
+
+// writeManifest records in dir that generator rendered files for the project
+// name with the given answers.
+func writeManifest(dir, generator, name string, answers any, files Files) error {
+	encoded, err := json.Marshal(answers)
+	if err != nil {
+		return err
+	}
+	manifest := Manifest{
+		Generator:       generator,
+		TemplateVersion: TemplateVersion,
+		Name:            name,
+		Answers:         encoded,
+		Files:           files,
+	}
+	return manifest.write(dir)
+}
+
+// marshal encodes the manifest as written to ManifestFile.
+func (m Manifest) marshal() ([]byte, error) {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+// write writes the manifest to the project in dir.
+func (m Manifest) write(dir string) error {
+	content, err := m.marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), content, 0644)
+}
+
+// ReadManifest reads the manifest of the project in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s has no %s; only projects generated by %s can be upgraded", dir, ManifestFile, strings.Join(upgradableGenerators(), ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", ManifestFile, err)
+	}
+	return &manifest, nil
+}
+
+// upgradableGenerators lists the create commands that record a manifest.
+func upgradableGenerators() []string {
+	return []string{"create-fastapi-skeleton", "create-flask-skeleton", "create-django-skeleton", "create-go-service", "create-node-service", "create-ml-project", "create-llm-app", "create-python-cli", "create-demo-app", "create-frontend"}
+}
+
+// UpgradeOptions configures Upgrade.
+type UpgradeOptions struct {
+	// ProjectDir is the root of a project with a manifest.
+	ProjectDir string
+
+	// DryRun reports what would change without writing anything.
+	DryRun bool
+
+	// Stdout receives progress messages. Nil discards them.
+	Stdout io.Writer
+}
+
+// UpgradeReport lists the files an upgrade changed, by slash-separated path
+// relative to the project root.
+type UpgradeReport struct {
+	FromVersion, ToVersion int
+
+	Updated   []string // merged cleanly with the user's changes
+	Created   []string // added by the new templates
+	Removed   []string // dropped by the new templates and not modified
+	Conflicts []string // merged with conflict markers
+	Skipped   []string // left alone: deleted locally, or dropped by the templates but modified
+}
+
+// Upgrade regenerates the project in opts.ProjectDir with the current
+// templates and the answers recorded in its manifest, and merges the
+// changes between the recorded render and the new one into the project's
+// files. Where the user changed the same lines as the templates, both
+// versions are kept between standard conflict markers. The manifest is then
+// updated, so the next upgrade merges from the new render.
+func Upgrade(ctx context.Context, opts UpgradeOptions) (*UpgradeReport, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stdout := opts.Stdout
+	if stdout == nil {
+		stdout = io.Discard
+	}
+
+	manifest, err := ReadManifest(opts.ProjectDir)
+	if err != nil {
+		return nil, err
+	}
+	render, ok := projectRenderers[manifest.Generator]
+	if !ok {
+		return nil, fmt.Errorf("projects generated by %q cannot be upgraded", manifest.Generator)
+	}
+	if manifest.TemplateVersion > TemplateVersion {
+		return nil, fmt.Errorf("project uses template version %d, newer than this CLI's %d; update infocusp first", manifest.TemplateVersion, TemplateVersion)
+	}
+	files, err := render(manifest.Name, manifest.Answers)
+	if err != nil {
+		return nil, fmt.Errorf("rendering templates: %w", err)
+	}
+
+	report := &UpgradeReport{FromVersion: manifest.TemplateVersion, ToVersion: TemplateVersion}
+	theirsLabel := fmt.Sprintf("template version %d", TemplateVersion)
+	changes := Files{}
+	var removals []string
+
+	for _, path := range files.Paths() {
+		base, inBase := manifest.Files[path]
+		current, err := os.ReadFile(filepath.Join(opts.ProjectDir, filepath.FromSlash(path)))
+		exists := err == nil
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		switch {
+		case !exists && inBase:
+			if base != files[path] {
+				report.Skipped = append(report.Skipped, path)
+			}
+		case !exists:
+			changes[path] = files[path]
+			report.Created = append(report.Created, path)
+		default:
+			merged, conflicts := merge3(base, string(current), files[path], "local", theirsLabel)
+			if merged == string(current) {
+				continue
+			}
+			changes[path] = merged
+			if conflicts > 0 {
+				report.Conflicts = append(report.Conflicts, path)
+			} else {
+				report.Updated = append(report.Updated, path)
+			}
+		}
+	}
+
+	for _, path := range manifest.Files.Paths() {
+		if _, ok := files[path]; ok {
+			continue
+		}
+		if _, ok := files[derivedFiles[path]]; ok {
+			continue
+		}
+		current, err := os.ReadFile(filepath.Join(opts.ProjectDir, filepath.FromSlash(path)))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if string(current) == manifest.Files[path] {
+			removals = append(removals, path)
+			report.Removed = append(report.Removed, path)
+		} else {
+			report.Skipped = append(report.Skipped, path)
+		}
+	}
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	fmt.Fprintf(stdout, "Upgrading from template version %d to %d...\n", report.FromVersion, report.ToVersion)
+	if err := changes.Write(opts.ProjectDir); err != nil {
+		return nil, err
+	}
+	for _, path := range removals {
+		if err := os.Remove(filepath.Join(opts.ProjectDir, filepath.FromSlash(path))); err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep the rest of the manifest, such as the added resources
+	manifest.TemplateVersion, manifest.Files = TemplateVersion, files
+	return report, manifest.write(opts.ProjectDir)
+}
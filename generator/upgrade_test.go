@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUpgrade(t *testing.T) {
+	answers := goServiceAnswers{Module: "example.com/shop"}
+	files, err := GoServiceFiles(GoServiceOptions{Options: Options{Name: "shop"}, Module: answers.Module})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dockerfile := splitLines(files["Dockerfile"])
+	rest := strings.Join(dockerfile[1:], "")
+
+	// base is the render of an older template version, local the project
+	// as the user left it
+	base, local := Files{}, Files{}
+	for path, content := range files {
+		base[path], local[path] = content, content
+	}
+	// The templates changed a file the user did not touch
+	base["README.md"] = strings.Replace(files["README.md"], "make run", "go run ./cmd/server", 1)
+	local["README.md"] = base["README.md"]
+	// The templates and the user changed different lines
+	base["Makefile"] = "# Generated by infocusp\n" + files["Makefile"]
+	local["Makefile"] = base["Makefile"] + "\nbench:\n\tgo test -bench=. ./...\n"
+	// The templates and the user changed the same line
+	base["Dockerfile"] = "# syntax=docker/dockerfile:1.4\n" + rest
+	local["Dockerfile"] = "# syntax=docker/dockerfile:1.7\n" + rest
+	// The templates added a file
+	delete(base, ".dockerignore")
+	delete(local, ".dockerignore")
+	// The templates dropped a file the user did not touch, and one they did
+	base["docs/ARCHITECTURE.md"], local["docs/ARCHITECTURE.md"] = "# Architecture\n", "# Architecture\n"
+	base["docs/DEPLOY.md"], local["docs/DEPLOY.md"] = "# Deploy\n", "# Deploy\n\nRun make docker.\n"
+	// The templates changed a file the user deleted
+	base["cmd/server/main.go"] = "// Command server serves the API.\n" + files["cmd/server/main.go"]
+	delete(local, "cmd/server/main.go")
+
+	dir := filepath.Join(t.TempDir(), "shop")
+	if err := writeProject(dir, local); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeManifest(dir, "create-go-service", "shop", answers, base); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest.TemplateVersion = TemplateVersion - 1
+	if err := manifest.write(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	want := UpgradeReport{
+		FromVersion: TemplateVersion - 1,
+		ToVersion:   TemplateVersion,
+		Updated:     []string{"Makefile", "README.md"},
+		Created:     []string{".dockerignore"},
+		Removed:     []string{"docs/ARCHITECTURE.md"},
+		Conflicts:   []string{"Dockerfile"},
+		Skipped:     []string{"cmd/server/main.go", "docs/DEPLOY.md"},
+	}
+
+	before := snapshot(t, dir)
+	report, err := Upgrade(context.Background(), UpgradeOptions{ProjectDir: dir, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprintf("%+v", *report); got != fmt.Sprintf("%+v", want) {
+		t.Errorf("dry run report = %s, want %+v", got, want)
+	}
+	after := snapshot(t, dir)
+	if len(after) != len(before) {
+		t.Errorf("dry run left %d files, want %d", len(after), len(before))
+	}
+	for path, content := range before {
+		if after[path] != content {
+			t.Errorf("dry run changed %s", path)
+		}
+	}
+
+	report, err = Upgrade(context.Background(), UpgradeOptions{ProjectDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprintf("%+v", *report); got != fmt.Sprintf("%+v", want) {
+		t.Errorf("report = %s, want %+v", got, want)
+	}
+
+	upgraded := Files{
+		"README.md":                 files["README.md"],
+		"Makefile":                  files["Makefile"] + "\nbench:\n\tgo test -bench=. ./...\n",
+		"Dockerfile":                fmt.Sprintf("<<<<<<< local\n# syntax=docker/dockerfile:1.7\n=======\n%s>>>>>>> template version %d\n%s", dockerfile[0], TemplateVersion, rest),
+		".dockerignore":             files[".dockerignore"],
+		"docs/DEPLOY.md":            local["docs/DEPLOY.md"],
+		"internal/server/server.go": files["internal/server/server.go"],
+	}
+	for path, content := range upgraded {
+		got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if err != nil {
+			t.Errorf("reading %s: %v", path, err)
+		} else if string(got) != content {
+			t.Errorf("%s:\n%s\nwant:\n%s", path, got, content)
+		}
+	}
+	for _, path := range []string{"docs/ARCHITECTURE.md", "cmd/server/main.go"} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); !os.IsNotExist(err) {
+			t.Errorf("%s exists after the upgrade", path)
+		}
+	}
+
+	manifest, err = ReadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.TemplateVersion != TemplateVersion {
+		t.Errorf("manifest template version = %d, want %d", manifest.TemplateVersion, TemplateVersion)
+	}
+	if len(manifest.Files) != len(files) || manifest.Files["README.md"] != files["README.md"] {
+		t.Error("manifest does not record the new render")
+	}
+}
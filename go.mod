@@ -5,6 +5,7 @@ go 1.23.0
 require (
 	github.com/go-git/go-git/v5 v5.12.0
 	github.com/manifoldco/promptui v0.9.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
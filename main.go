@@ -1,6 +1,10 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+
 	"infocusp-projects/commands"
 
 	"github.com/spf13/cobra"
@@ -14,9 +18,23 @@ import (
 //
 //	Run `infocusp` followed by a command to create a project skeleton.
 //	Example commands:
-//	- infocusp create-react-app: Create a React app with optional features like Tailwind CSS and TypeScript.
+//	- infocusp create-react-skeleton: Create a React app with optional features like Tailwind CSS and TypeScript.
+//	- infocusp create-frontend: Create a React, Vue, SvelteKit or Angular app with shared TypeScript, Tailwind, lint and test options.
 //	- infocusp create-flask-skeleton: Create a basic Flask project structure.
 //	- infocusp create-fastapi-skeleton: Create a basic FastAPI project structure with optional testing and Google project settings.
+//	- infocusp create-django-skeleton: Create a Django project with an admin, a REST API and per-environment settings.
+//	- infocusp create-go-service: Create a Go HTTP service with a chosen router, tests and a distroless Dockerfile.
+//	- infocusp create-node-service: Create an Express or NestJS service with validation, tests and Docker support.
+//	- infocusp create-python-cli: Create a src-layout Python package with a Typer or Click command-line interface.
+//	- infocusp create-ml-project: Create a machine learning project with config-driven training, experiment tracking and DVC.
+//	- infocusp create-llm-app: Create a chat app streaming from Ollama or an OpenAI-compatible API, with an optional React frontend.
+//	- infocusp create-demo-app: Create a Streamlit or Gradio demo with data and model hooks for client demos.
+//	- infocusp create-fullstack: Create a React frontend and a FastAPI backend wired together in one repository.
+//	- infocusp doctor: Check the installed tools and which stacks can be generated.
+//	- infocusp add resource Order id:int total:float: Add CRUD endpoints for a resource to a Flask or FastAPI project.
+//	- infocusp add api-client --from-openapi spec.yaml: Generate a typed TypeScript API client and hooks into a React project.
+//	- infocusp demo list: List the demo projects of the catalog, and create one with `infocusp demo create <name>`.
+//	- infocusp upgrade: Merge improvements of the templates into a generated project.
 //
 // Features:
 //   - Generate skeletons for popular frameworks.
@@ -48,17 +66,60 @@ Happy Coding! 😎
 	// Add command for creating a React app.
 	rootCmd.AddCommand(commands.CreateReactAppCmd())
 
+	// Add command for creating a React, Vue, SvelteKit or Angular app.
+	rootCmd.AddCommand(commands.CreateFrontendCmd())
+
 	// Add command for creating a Flask skeleton project.
 	rootCmd.AddCommand(commands.CreateFlaskSkeletonCmd())
 
 	// Add command for creating a FastAPI skeleton project.
 	rootCmd.AddCommand(commands.CreateFastAPISkeletonCmd())
 
+	// Add command for creating a Django skeleton project.
+	rootCmd.AddCommand(commands.CreateDjangoSkeletonCmd())
+
+	// Add command for creating a Go microservice.
+	rootCmd.AddCommand(commands.CreateGoServiceCmd())
+
+	// Add command for creating an Express or NestJS service.
+	rootCmd.AddCommand(commands.CreateNodeServiceCmd())
+
+	// Add command for creating a Python command-line tool.
+	rootCmd.AddCommand(commands.CreatePythonCLICmd())
+
+	// Add command for creating a machine learning project.
+	rootCmd.AddCommand(commands.CreateMLProjectCmd())
+
+	// Add command for creating an LLM chat application.
+	rootCmd.AddCommand(commands.CreateLLMAppCmd())
+
+	// Add command for creating a Streamlit or Gradio demo app.
+	rootCmd.AddCommand(commands.CreateDemoAppCmd())
+
+	// Add command for creating a React and FastAPI full-stack project.
+	rootCmd.AddCommand(commands.CreateFullstackCmd())
+
+	// Add command for checking the toolchain the generators rely on.
+	rootCmd.AddCommand(commands.DoctorCmd())
+
+	// Add commands for adding code, such as resources, to generated projects.
+	rootCmd.AddCommand(commands.AddCmd())
+
+	// Add command for upgrading generated projects to newer templates.
+	rootCmd.AddCommand(commands.UpgradeCmd())
+
+	// Add commands for listing, describing and creating the catalog's demo projects.
+	rootCmd.AddCommand(commands.DemoCmd())
+
 	// Add command for cloning from a list of repositories
 	rootCmd.AddCommand(commands.CloneRepoCmd())
 
+	// Cancel running generators and the tools they spawn on Ctrl+C.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	// Execute the root command to start the CLI.
-	// This will listen for any subcommands (such as 'create-react-app', 'create-flask-skeleton', etc.)
+	// This will listen for any subcommands (such as 'create-react-skeleton', 'create-flask-skeleton', etc.)
 	// and delegate the processing to the respective functions.
-	rootCmd.Execute()
+	rootCmd.ExecuteContext(ctx)
 }